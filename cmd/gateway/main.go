@@ -12,6 +12,7 @@ import (
 	"go-im/pkg/logger"
 	"go-im/pkg/response"
 	"go-im/pkg/server"
+	"go-im/pkg/storage"
 	"go-im/pkg/util"
 	"go-im/pkg/util/consul"
 	"net/http"
@@ -105,6 +106,9 @@ func (s *GinServer) setRouter() *gin.Engine {
 	// 静态页面
 	r.LoadHTMLFiles("static/index.html")
 	r.Static("/static", "./static")
+	if config.C.Upload.Driver != storage.DriverS3 {
+		r.Static(config.UploadUrlPath, config.C.Upload.LocalDir) // 本地上传的文件
+	}
 	r.GET("/", func(c *gin.Context) {
 		c.HTML(http.StatusOK, "index.html", gin.H{
 			"gateway_host": config.C.GetGatewayHost(),
@@ -115,7 +119,9 @@ func (s *GinServer) setRouter() *gin.Engine {
 	r.GET("/proxy", proxy.Handle)
 
 	g := r.Group("go-im")
-	api.RegisterUser(g) // 注册用户
+	api.RegisterUser(g)   // 注册用户
+	api.RegisterUpload(g) // 文件上传
+	api.RegisterFriend(g) // 好友
 
 	return r
 }
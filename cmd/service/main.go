@@ -33,7 +33,7 @@ func main() {
 	conn = connect.InitServer(*addr)
 
 	// 初始化服务
-	app.Init()
+	app.Init(conn.ServerId())
 
 	hook := server.NewHook()
 	hook.Close(func(sg os.Signal) {
@@ -5,6 +5,7 @@ import (
 	"go-im/pkg/logger"
 	"go-im/pkg/mysql"
 	"go-im/pkg/redis"
+	"go-im/pkg/storage"
 	"go-im/pkg/util"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -15,6 +16,9 @@ import (
 
 var C = NewConfig()
 
+// UploadUrlPath 网关本地文件访问路径
+const UploadUrlPath = "/uploads"
+
 func NewConfig() *Config {
 	return &Config{
 		App: App{
@@ -56,6 +60,29 @@ func NewConfig() *Config {
 				Database:   "go_im",
 			},
 		},
+		Message: Message{
+			OfflineLimit:  500,
+			OfflineExpire: 7 * 86400,
+			DedupExpire:   300,
+			RecallWindow:  120,
+			EditWindow:    300,
+		},
+		Room: Room{
+			OwnerQuota: 10,
+			MaxMembers: 500,
+		},
+		Upload: Upload{
+			Driver:        storage.DriverLocal,
+			LocalDir:      "./uploads",
+			MaxImageSize:  20 << 20,
+			MaxFileSize:   100 << 20,
+			ThumbnailSize: 200,
+			FileExts:      []string{".txt", ".pdf", ".doc", ".docx", ".xls", ".xlsx", ".ppt", ".pptx", ".zip", ".rar", ".7z", ".mp3", ".mp4", ".jpg", ".jpeg", ".png", ".gif"},
+			S3: S3{
+				Region:    "us-east-1",
+				PathStyle: true,
+			},
+		},
 	}
 }
 
@@ -66,6 +93,9 @@ type Config struct {
 	Logging Logging `toml:"logging" yaml:"logging" mapstructure:"logging"`
 	Redis   Redis   `toml:"redis" yaml:"redis" mapstructure:"redis"`
 	Mysql   []Mysql `toml:"mysql" yaml:"mysql" mapstructure:"mysql"`
+	Message Message `toml:"message" yaml:"message" mapstructure:"message"`
+	Room    Room    `toml:"room" yaml:"room" mapstructure:"room"`
+	Upload  Upload  `toml:"upload" yaml:"upload" mapstructure:"upload"`
 }
 
 // GetGatewayHost 获取网关地址
@@ -180,6 +210,65 @@ type Logging struct {
 	Level    string `toml:"level" yaml:"level" mapstructure:"level" env:"LOGGING_LEVEL"`
 }
 
+// Message 消息配置
+type Message struct {
+	OfflineLimit  int64 `toml:"offline_limit" yaml:"offline_limit" mapstructure:"offline_limit" env:"MESSAGE_OFFLINE_LIMIT"`     // 每个用户最多保存的离线消息数量
+	OfflineExpire int64 `toml:"offline_expire" yaml:"offline_expire" mapstructure:"offline_expire" env:"MESSAGE_OFFLINE_EXPIRE"` // 离线消息过期时间（秒）
+	DedupExpire   int64 `toml:"dedup_expire" yaml:"dedup_expire" mapstructure:"dedup_expire" env:"MESSAGE_DEDUP_EXPIRE"`         // 客户端消息id去重的有效时间（秒）
+	RecallWindow  int64 `toml:"recall_window" yaml:"recall_window" mapstructure:"recall_window" env:"MESSAGE_RECALL_WINDOW"`     // 消息发送后允许撤回的时间（秒）
+	EditWindow    int64 `toml:"edit_window" yaml:"edit_window" mapstructure:"edit_window" env:"MESSAGE_EDIT_WINDOW"`             // 消息发送后允许编辑的时间（秒）
+}
+
+// Room 房间配置
+type Room struct {
+	OwnerQuota int64 `toml:"owner_quota" yaml:"owner_quota" mapstructure:"owner_quota" env:"ROOM_OWNER_QUOTA"` // 每个用户最多可创建的房间数量，0 表示不限制
+	MaxMembers int64 `toml:"max_members" yaml:"max_members" mapstructure:"max_members" env:"ROOM_MAX_MEMBERS"` // 房间成员上限（房间可单独设置更低的上限），0 表示不限制
+}
+
+// Upload 文件上传配置
+type Upload struct {
+	Driver        string   `toml:"driver" yaml:"driver" mapstructure:"driver" env:"UPLOAD_DRIVER"`                                 // 存储方式：local、s3
+	LocalDir      string   `toml:"local_dir" yaml:"local_dir" mapstructure:"local_dir" env:"UPLOAD_LOCAL_DIR"`                     // 本地存储目录
+	BaseUrl       string   `toml:"base_url" yaml:"base_url" mapstructure:"base_url" env:"UPLOAD_BASE_URL"`                         // 文件访问地址前缀，本地存储为空时使用网关地址
+	MaxImageSize  int64    `toml:"max_image_size" yaml:"max_image_size" mapstructure:"max_image_size" env:"UPLOAD_MAX_IMAGE_SIZE"` // 图片大小上限（字节）
+	MaxFileSize   int64    `toml:"max_file_size" yaml:"max_file_size" mapstructure:"max_file_size" env:"UPLOAD_MAX_FILE_SIZE"`     // 文件大小上限（字节）
+	ThumbnailSize int      `toml:"thumbnail_size" yaml:"thumbnail_size" mapstructure:"thumbnail_size" env:"UPLOAD_THUMBNAIL_SIZE"` // 缩略图最长边（像素）
+	FileExts      []string `toml:"file_exts" yaml:"file_exts" mapstructure:"file_exts"`                                            // 允许上传的文件后缀
+	S3            S3       `toml:"s3" yaml:"s3" mapstructure:"s3"`
+}
+
+// S3 兼容 s3 协议的对象存储配置（aws s3、minio 等）
+type S3 struct {
+	Endpoint  string `toml:"endpoint" yaml:"endpoint" mapstructure:"endpoint" env:"UPLOAD_S3_ENDPOINT"`
+	Region    string `toml:"region" yaml:"region" mapstructure:"region" env:"UPLOAD_S3_REGION"`
+	Bucket    string `toml:"bucket" yaml:"bucket" mapstructure:"bucket" env:"UPLOAD_S3_BUCKET"`
+	AccessKey string `toml:"access_key" yaml:"access_key" mapstructure:"access_key" env:"UPLOAD_S3_ACCESS_KEY"`
+	SecretKey string `toml:"secret_key" yaml:"secret_key" mapstructure:"secret_key" env:"UPLOAD_S3_SECRET_KEY"`
+	PathStyle bool   `toml:"path_style" yaml:"path_style" mapstructure:"path_style" env:"UPLOAD_S3_PATH_STYLE"` // 使用 endpoint/bucket/key 形式的地址
+}
+
+// StorageConfig 获取文件存储配置
+func (u *Upload) StorageConfig() storage.Config {
+	baseUrl := u.BaseUrl
+	if baseUrl == "" && u.Driver != storage.DriverS3 {
+		baseUrl = C.GetGatewayHost() + UploadUrlPath
+	}
+	return storage.Config{
+		Driver:   u.Driver,
+		LocalDir: u.LocalDir,
+		BaseUrl:  baseUrl,
+		S3: storage.S3Config{
+			Endpoint:  u.S3.Endpoint,
+			Region:    u.S3.Region,
+			Bucket:    u.S3.Bucket,
+			AccessKey: u.S3.AccessKey,
+			SecretKey: u.S3.SecretKey,
+			PathStyle: u.S3.PathStyle,
+			PublicUrl: u.BaseUrl,
+		},
+	}
+}
+
 type Redis struct {
 	Name        string `toml:"name" yaml:"name" mapstructure:"name" env:"REDIS_NAME"` // 配置唯一标识
 	Addr        string `toml:"addr" yaml:"addr" mapstructure:"addr" env:"REDIS_ADDR"`
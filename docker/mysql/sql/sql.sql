@@ -15,4 +15,159 @@ CREATE TABLE if not exists `user`
 ) ENGINE = InnoDB
   DEFAULT CHARSET = utf8mb4
   COLLATE = utf8mb4_general_ci
-  ROW_FORMAT = DYNAMIC COMMENT ='用户表';
\ No newline at end of file
+  ROW_FORMAT = DYNAMIC COMMENT ='用户表';
+
+CREATE TABLE if not exists `user_block`
+(
+    `id`         bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+    `user_id`    int unsigned    NOT NULL DEFAULT 0 COMMENT '用户id',
+    `block_id`   int unsigned    NOT NULL DEFAULT 0 COMMENT '被屏蔽的用户id',
+    `created_at` timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '屏蔽时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_block` (`user_id`, `block_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci
+  ROW_FORMAT = DYNAMIC COMMENT ='用户屏蔽列表';
+
+CREATE TABLE if not exists `message`
+(
+    `id`         bigint unsigned  NOT NULL AUTO_INCREMENT COMMENT '主键',
+    `msg_type`   tinyint unsigned NOT NULL DEFAULT 0 COMMENT '消息类型：1 群聊 2 私聊',
+    `from_uid`   int unsigned     NOT NULL DEFAULT 0 COMMENT '发送者',
+    `room_id`    bigint unsigned  NOT NULL DEFAULT 0 COMMENT '房间id（群聊消息）',
+    `to_uid`     int unsigned     NOT NULL DEFAULT 0 COMMENT '接收者（私聊消息）',
+    `seq`        bigint unsigned  NOT NULL DEFAULT 0 COMMENT '会话内消息序号',
+    `request_id` varchar(64)      NOT NULL DEFAULT '' COMMENT '客户端消息id',
+    `content`    text             NOT NULL COMMENT '消息内容（json）',
+    `status`     tinyint unsigned NOT NULL DEFAULT 0 COMMENT '状态：0 正常 1 已编辑 2 已撤回',
+    `created_at` timestamp(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+    `updated_at` timestamp(3)     NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_room_id` (`room_id`, `id`),
+    KEY `idx_room_seq` (`room_id`, `seq`),
+    KEY `idx_from_to` (`from_uid`, `to_uid`, `id`),
+    KEY `idx_from_to_seq` (`from_uid`, `to_uid`, `seq`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci
+  ROW_FORMAT = DYNAMIC COMMENT ='聊天消息表';
+
+CREATE TABLE if not exists `message_read`
+(
+    `id`         bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+    `user_id`    int unsigned    NOT NULL DEFAULT 0 COMMENT '用户id',
+    `room_id`    bigint unsigned NOT NULL DEFAULT 0 COMMENT '房间id（群聊）',
+    `peer_uid`   int unsigned    NOT NULL DEFAULT 0 COMMENT '对方用户id（私聊）',
+    `msg_id`     bigint unsigned NOT NULL DEFAULT 0 COMMENT '已读到的消息id',
+    `created_at` timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_conversation` (`user_id`, `room_id`, `peer_uid`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci
+  ROW_FORMAT = DYNAMIC COMMENT ='消息已读位置表';
+
+CREATE TABLE if not exists `room`
+(
+    `id`            bigint unsigned  NOT NULL AUTO_INCREMENT COMMENT '房间id',
+    `owner_id`      int unsigned     NOT NULL DEFAULT 0 COMMENT '创建者',
+    `name`          varchar(50)      NOT NULL DEFAULT '' COMMENT '房间名称',
+    `avatar`        varchar(255)     NOT NULL DEFAULT '' COMMENT '房间头像',
+    `description`   varchar(200)     NOT NULL DEFAULT '' COMMENT '房间简介',
+    `announcement`  varchar(1000)    NOT NULL DEFAULT '' COMMENT '房间公告',
+    `visibility`    tinyint unsigned NOT NULL DEFAULT 1 COMMENT '可见性：1 公开 2 私有',
+    `join_approval` tinyint(1)       NOT NULL DEFAULT 0 COMMENT '加入是否需要审核',
+    `max_members`   int unsigned     NOT NULL DEFAULT 0 COMMENT '成员上限，0 表示使用默认上限',
+    `settings`      text             NOT NULL COMMENT '房间设置（json）',
+    `status`        tinyint unsigned NOT NULL DEFAULT 1 COMMENT '状态：1 正常 2 已关闭',
+    `created_at`    timestamp        NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at`    timestamp        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_owner_id` (`owner_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci
+  ROW_FORMAT = DYNAMIC COMMENT ='房间表';
+
+CREATE TABLE if not exists `room_member`
+(
+    `id`         bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+    `room_id`    bigint unsigned NOT NULL DEFAULT 0 COMMENT '房间id',
+    `user_id`    int unsigned    NOT NULL DEFAULT 0 COMMENT '用户id',
+    `role`       tinyint unsigned NOT NULL DEFAULT 0 COMMENT '角色：0普通成员 1管理员（创建者记录在房间表中）',
+    `created_at` timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '加入时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_room_user` (`room_id`, `user_id`),
+    KEY `idx_user_id` (`user_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci
+  ROW_FORMAT = DYNAMIC COMMENT ='房间成员表';
+
+CREATE TABLE if not exists `room_invite`
+(
+    `id`         bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+    `room_id`    bigint unsigned NOT NULL DEFAULT 0 COMMENT '房间id',
+    `code`       varchar(16)     NOT NULL DEFAULT '' COMMENT '邀请码',
+    `creator_id` int unsigned    NOT NULL DEFAULT 0 COMMENT '创建者',
+    `max_uses`   int unsigned    NOT NULL DEFAULT 0 COMMENT '最多使用次数，0 表示不限制',
+    `used_count` int unsigned    NOT NULL DEFAULT 0 COMMENT '已使用次数',
+    `expire_at`  bigint unsigned NOT NULL DEFAULT 0 COMMENT '过期时间（秒级时间戳），0 表示永久有效',
+    `created_at` timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_code` (`code`),
+    KEY `idx_room_id` (`room_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci
+  ROW_FORMAT = DYNAMIC COMMENT ='房间邀请码表';
+
+CREATE TABLE if not exists `room_join_request`
+(
+    `id`         bigint unsigned  NOT NULL AUTO_INCREMENT COMMENT '主键',
+    `room_id`    bigint unsigned  NOT NULL DEFAULT 0 COMMENT '房间id',
+    `user_id`    int unsigned     NOT NULL DEFAULT 0 COMMENT '申请人',
+    `remark`     varchar(100)     NOT NULL DEFAULT '' COMMENT '申请说明',
+    `status`     tinyint unsigned NOT NULL DEFAULT 0 COMMENT '状态：0 待审核 1 已通过 2 已拒绝',
+    `handler_id` int unsigned     NOT NULL DEFAULT 0 COMMENT '审核人',
+    `created_at` timestamp        NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '申请时间',
+    `updated_at` timestamp        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_room_status` (`room_id`, `status`),
+    KEY `idx_user_id` (`user_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci
+  ROW_FORMAT = DYNAMIC COMMENT ='房间加入申请表';
+
+CREATE TABLE if not exists `friend`
+(
+    `id`         bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+    `user_id`    int unsigned    NOT NULL DEFAULT 0 COMMENT '用户id',
+    `friend_id`  int unsigned    NOT NULL DEFAULT 0 COMMENT '好友id',
+    `created_at` timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_friend` (`user_id`, `friend_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci
+  ROW_FORMAT = DYNAMIC COMMENT ='好友关系表';
+
+CREATE TABLE if not exists `friend_request`
+(
+    `id`         bigint unsigned  NOT NULL AUTO_INCREMENT COMMENT '主键',
+    `from_uid`   int unsigned     NOT NULL DEFAULT 0 COMMENT '申请人',
+    `to_uid`     int unsigned     NOT NULL DEFAULT 0 COMMENT '被申请人',
+    `remark`     varchar(100)     NOT NULL DEFAULT '' COMMENT '申请说明',
+    `status`     tinyint unsigned NOT NULL DEFAULT 0 COMMENT '状态：0 待处理 1 已通过 2 已拒绝',
+    `created_at` timestamp        NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '申请时间',
+    `updated_at` timestamp        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_to_status` (`to_uid`, `status`),
+    KEY `idx_from_to` (`from_uid`, `to_uid`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci
+  ROW_FORMAT = DYNAMIC COMMENT ='好友申请表';
@@ -0,0 +1,6 @@
+-- 已有部署的增量变更，在 sql.sql 之后执行（仅执行一次）
+
+ALTER TABLE `user`
+    ADD COLUMN `avatar` varchar(255) NOT NULL DEFAULT '' COMMENT '头像' AFTER `nickname`,
+    ADD COLUMN `bio`    varchar(200) NOT NULL DEFAULT '' COMMENT '个人简介' AFTER `avatar`,
+    ADD KEY `idx_nickname` (`nickname`);
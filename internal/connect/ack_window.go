@@ -0,0 +1,114 @@
+package connect
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	AckWindowSize  = 1000             // 每个连接最多等待确认的消息数量
+	AckTimeout     = 10 * time.Second // 消息确认超时时间，超时后重新投递
+	AckMaxRetry    = 3                // 最大重新投递次数
+	ackCheckPeriod = 5 * time.Second  // 检查超时消息的时间周期
+	AckQueryKey    = "ack"            // 连接参数，值为 1 时表示客户端支持消息确认
+)
+
+// 等待确认的消息
+type pendingMsg struct {
+	msgId    uint64
+	data     []byte
+	sendTime time.Time
+	retry    uint8
+}
+
+// AckWindow 等待客户端确认的消息窗口
+type AckWindow struct {
+	lock  sync.Mutex
+	size  int
+	list  *list.List // 按投递顺序排列
+	items map[uint64]*list.Element
+}
+
+func NewAckWindow(size int) *AckWindow {
+	return &AckWindow{
+		size:  size,
+		list:  list.New(),
+		items: make(map[uint64]*list.Element, size),
+	}
+}
+
+// Add 添加等待确认的消息，窗口已满时丢弃最早的消息
+func (w *AckWindow) Add(msgId uint64, data []byte) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if e, ok := w.items[msgId]; ok {
+		w.list.Remove(e)
+	}
+
+	if w.list.Len() >= w.size {
+		front := w.list.Front()
+		w.list.Remove(front)
+		delete(w.items, front.Value.(*pendingMsg).msgId)
+	}
+
+	w.items[msgId] = w.list.PushBack(&pendingMsg{
+		msgId:    msgId,
+		data:     data,
+		sendTime: time.Now(),
+	})
+}
+
+// Ack 确认消息
+func (w *AckWindow) Ack(msgIds ...uint64) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for _, msgId := range msgIds {
+		if e, ok := w.items[msgId]; ok {
+			w.list.Remove(e)
+			delete(w.items, msgId)
+		}
+	}
+}
+
+// Timeout 获取确认超时且未超过最大重试次数的消息（按投递顺序），并更新重试信息
+func (w *AckWindow) Timeout(now time.Time) [][]byte {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	var result [][]byte
+	for e := w.list.Front(); e != nil; e = e.Next() {
+		msg := e.Value.(*pendingMsg)
+		if msg.retry >= AckMaxRetry || now.Sub(msg.sendTime) < AckTimeout {
+			continue
+		}
+		msg.retry++
+		msg.sendTime = now
+		result = append(result, msg.data)
+	}
+	return result
+}
+
+// Pending 取出全部未确认的消息（按投递顺序），并清空窗口
+func (w *AckWindow) Pending() [][]byte {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	result := make([][]byte, 0, w.list.Len())
+	for e := w.list.Front(); e != nil; e = e.Next() {
+		result = append(result, e.Value.(*pendingMsg).data)
+	}
+
+	w.list.Init()
+	w.items = make(map[uint64]*list.Element, w.size)
+	return result
+}
+
+// Len 等待确认的消息数量
+func (w *AckWindow) Len() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.list.Len()
+}
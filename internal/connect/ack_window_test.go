@@ -0,0 +1,53 @@
+package connect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAckWindowAck(t *testing.T) {
+	w := NewAckWindow(10)
+	w.Add(1, []byte("a"))
+	w.Add(2, []byte("b"))
+	w.Add(3, []byte("c"))
+	assert.Equal(t, 3, w.Len())
+
+	w.Ack(2, 4) // 不存在的消息忽略
+	assert.Equal(t, 2, w.Len())
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("c")}, w.Pending())
+	assert.Equal(t, 0, w.Len())
+}
+
+func TestAckWindowFull(t *testing.T) {
+	w := NewAckWindow(2)
+	w.Add(1, []byte("a"))
+	w.Add(2, []byte("b"))
+	w.Add(1, []byte("a2")) // 重复添加移动到末尾
+	w.Add(3, []byte("c"))  // 窗口已满，丢弃最早的消息 2
+
+	assert.Equal(t, 2, w.Len())
+	assert.Equal(t, [][]byte{[]byte("a2"), []byte("c")}, w.Pending())
+}
+
+func TestAckWindowTimeout(t *testing.T) {
+	w := NewAckWindow(10)
+	w.Add(1, []byte("a"))
+	w.Add(2, []byte("b"))
+
+	now := time.Now()
+	assert.Empty(t, w.Timeout(now))
+
+	w.Ack(2)
+	for i := 1; i <= AckMaxRetry; i++ {
+		now = now.Add(AckTimeout)
+		assert.Equal(t, [][]byte{[]byte("a")}, w.Timeout(now), "retry %d", i)
+		// 重新投递后重新计时
+		assert.Empty(t, w.Timeout(now.Add(AckTimeout-time.Millisecond)))
+	}
+
+	// 超过最大重试次数不再投递，但仍保留在窗口中
+	assert.Empty(t, w.Timeout(now.Add(AckTimeout)))
+	assert.Equal(t, 1, w.Len())
+}
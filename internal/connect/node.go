@@ -28,19 +28,29 @@ func WithNodeLoginTime(t int64) NodeOpt {
 	}
 }
 
+// WithNodeAck 客户端是否支持消息确认
+func WithNodeAck(enabled bool) NodeOpt {
+	return func(node *Node) {
+		node.AckEnabled = enabled
+	}
+}
+
 type Node struct {
-	CloseLock       sync.Mutex      // WS互斥锁
-	Conn            *websocket.Conn // websocket连接
-	UserId          uint64          // 用户ID
-	RoomId          uint64          // 订阅的房间ID
-	HeartbeatTime   int64           // 心跳时间
-	HeartbeatErrNum uint8           // 心跳错误次数
-	LoginTime       int64           // 登录时间
-	DataQueue       chan []byte     // 消息队列
-	BroadcastQueue  chan []byte     // 广播消息
-	ServerAddr      string          // 服务器地址
-	ServerId        string          // 服务器ID
-	IsClose         bool            // 是否已关闭
+	CloseLock       sync.Mutex          // WS互斥锁
+	Conn            *websocket.Conn     // websocket连接
+	UserId          uint64              // 用户ID
+	HeartbeatTime   int64               // 心跳时间
+	HeartbeatErrNum uint8               // 心跳错误次数
+	LoginTime       int64               // 登录时间
+	DataQueue       chan []byte         // 消息队列
+	BroadcastQueue  chan []byte         // 广播消息
+	AckWindow       *AckWindow          // 等待客户端确认的消息
+	AckEnabled      bool                // 客户端是否支持消息确认，不支持时消息不进入确认窗口，也不会重新投递
+	ServerAddr      string              // 服务器地址
+	ServerId        string              // 服务器ID
+	IsClose         bool                // 是否已关闭
+	rooms           map[uint64]struct{} // 订阅的房间ID
+	roomLock        sync.RWMutex        // 订阅房间互斥锁
 }
 
 func NewNode(conn *websocket.Conn, userId uint64, serverAddr, ServerId string, opts ...NodeOpt) *Node {
@@ -52,6 +62,8 @@ func NewNode(conn *websocket.Conn, userId uint64, serverAddr, ServerId string, o
 		LoginTime:      nowTime,
 		DataQueue:      make(chan []byte, MsgDefaultChannelSize),
 		BroadcastQueue: make(chan []byte, MsgDefaultChannelSize),
+		AckWindow:      NewAckWindow(AckWindowSize),
+		rooms:          make(map[uint64]struct{}),
 		ServerAddr:     serverAddr,
 		ServerId:       ServerId,
 	}
@@ -87,11 +99,21 @@ func (n *Node) handleRead() {
 	}
 }
 
+// PushMsg 投递需要客户端确认的消息（msgId 为 0 或客户端不支持消息确认时不需要确认）
+func (n *Node) PushMsg(msgId uint64, data []byte) {
+	if msgId > 0 && n.AckEnabled {
+		n.AckWindow.Add(msgId, data)
+	}
+	n.DataQueue <- data
+}
+
 // 处理消息写请求（给当前连接发送消息）
 func (n *Node) handleWrite() {
 	ticker := time.NewTicker(pingPeriod)
+	ackTicker := time.NewTicker(ackCheckPeriod)
 	defer func() {
 		ticker.Stop()
+		ackTicker.Stop()
 		//n.Close()
 	}()
 
@@ -130,6 +152,15 @@ func (n *Node) handleWrite() {
 			} else {
 				n.HeartbeatTime = time.Now().Unix() // 更新心跳时间
 			}
+		case now := <-ackTicker.C: // 重新投递确认超时的消息
+			for _, qData := range n.AckWindow.Timeout(now) {
+				logger.Debugf("用户id：%d 重新投递消息：%s", n.UserId, qData)
+				_ = n.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+				if err := n.Conn.WriteMessage(websocket.TextMessage, qData); err != nil {
+					logger.Error("redelivery msg error", zap.Error(err))
+					return
+				}
+			}
 		}
 	}
 }
@@ -30,7 +30,7 @@ func DeleteNode(userId uint64) {
 func PushAll(data *types.QueueMsgData) {
 	NodesManger.Range(func(key, value any) bool {
 		node := value.(*Node)
-		node.DataQueue <- data.MarshalOutput(node.RoomId)
+		node.DataQueue <- data.MarshalOutput(data.RoomId)
 		return true
 	})
 }
@@ -0,0 +1,42 @@
+package connect
+
+/**
+ * @Description: 连接订阅的房间（一个连接可以同时订阅多个房间）
+ */
+
+// JoinRoom 订阅房间
+func (n *Node) JoinRoom(roomId uint64) {
+	n.roomLock.Lock()
+	defer n.roomLock.Unlock()
+
+	n.rooms[roomId] = struct{}{}
+}
+
+// LeaveRoom 取消订阅房间
+func (n *Node) LeaveRoom(roomId uint64) {
+	n.roomLock.Lock()
+	defer n.roomLock.Unlock()
+
+	delete(n.rooms, roomId)
+}
+
+// InRoom 是否订阅了房间
+func (n *Node) InRoom(roomId uint64) bool {
+	n.roomLock.RLock()
+	defer n.roomLock.RUnlock()
+
+	_, ok := n.rooms[roomId]
+	return ok
+}
+
+// RoomIds 获取订阅的全部房间
+func (n *Node) RoomIds() []uint64 {
+	n.roomLock.RLock()
+	defer n.roomLock.RUnlock()
+
+	ids := make([]uint64, 0, len(n.rooms))
+	for id := range n.rooms {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -78,6 +78,11 @@ func (c *WsConn) StartServer() {
 	}
 }
 
+// ServerId 服务id
+func (c *WsConn) ServerId() string {
+	return c.serverId
+}
+
 // Close 服务关闭
 func (c *WsConn) Close() {
 	// 注销 consul
@@ -114,10 +119,15 @@ func (c *WsConn) handleConn(w http.ResponseWriter, r *http.Request) {
 		wsConn.Close()
 		return
 	}
-	node := NewNode(wsConn, userId, addr.String(), c.serverId, WithNodeLoginTime(time.Now().Unix()))
+	node := NewNode(wsConn, userId, addr.String(), c.serverId,
+		WithNodeLoginTime(time.Now().Unix()),
+		WithNodeAck(r.URL.Query().Get(AckQueryKey) == "1"),
+	)
 
 	// 用户跟节点的映射
 	SetNode(userId, node)
+
+	event.RoomEvent.Publish(event.OpenConn, node)
 }
 
 // 处理网关连接
@@ -8,6 +8,7 @@ import (
 
 const (
 	ReadMsg    = "room:readMsg"    // 接收到客户端消息事件
+	OpenConn   = "room:openConn"   // 客户端连接建立事件
 	CloseConn  = "room:closeConn"  // 客户端连接关闭事件
 	GatewayMsg = "room:gatewayMsg" // 网关广播事件
 )
@@ -3,6 +3,8 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 	"go-im/internal/gateway/api/middleware"
+	friendApp "go-im/internal/logic/friend/app"
+	uploadApp "go-im/internal/logic/upload/app"
 	"go-im/internal/logic/user/app"
 )
 
@@ -17,4 +19,43 @@ func RegisterUser(r *gin.RouterGroup) {
 
 		authGroup.Use(middleware.JwtAuth()).GET("/service", auth.GetImServer) // 获取服务器地址
 	}
+
+	// 用户
+	userGroup := r.Group("user").Use(middleware.JwtAuth())
+	{
+		profile := app.NewProfileApp()
+		userGroup.GET("/profile", profile.Profile) // 个人资料
+		userGroup.PUT("/profile", profile.Update)  // 修改个人资料
+		userGroup.GET("/search", profile.Search)   // 搜索用户
+		userGroup.GET("/:id", profile.Show)        // 用户资料
+
+		block := app.NewBlockApp()
+		userGroup.GET("/block", block.List)           // 屏蔽列表
+		userGroup.POST("/block", block.Block)         // 屏蔽用户
+		userGroup.DELETE("/block/:id", block.Unblock) // 取消屏蔽
+	}
+}
+
+func RegisterUpload(r *gin.RouterGroup) {
+	// 文件上传
+	uploadGroup := r.Group("upload").Use(middleware.JwtAuth())
+	{
+		upload := uploadApp.NewUploadApp()
+		uploadGroup.POST("/image", upload.Image) // 上传图片
+		uploadGroup.POST("/file", upload.File)   // 上传文件
+	}
+}
+
+func RegisterFriend(r *gin.RouterGroup) {
+	// 好友
+	friendGroup := r.Group("friend").Use(middleware.JwtAuth())
+	{
+		friend := friendApp.NewFriendApp()
+		friendGroup.GET("", friend.List)                       // 好友列表
+		friendGroup.DELETE("/:id", friend.Delete)              // 删除好友
+		friendGroup.POST("/request", friend.SendRequest)       // 发送好友申请
+		friendGroup.GET("/request", friend.RequestList)        // 收到的好友申请
+		friendGroup.POST("/request/:id/accept", friend.Accept) // 通过好友申请
+		friendGroup.POST("/request/:id/reject", friend.Reject) // 拒绝好友申请
+	}
 }
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-im/internal/logic/friend"
+	"go-im/internal/logic/friend/service"
+	"go-im/pkg/response"
+	"go-im/pkg/util"
+	"go-im/pkg/util/context"
+)
+
+func NewFriendApp() *FriendApp {
+	return &FriendApp{
+		friendService: service.NewFriendService(),
+	}
+}
+
+type FriendApp struct {
+	friendService service.IService
+}
+
+// List 好友列表
+func (a *FriendApp) List(c *gin.Context) {
+	userId, _ := context.UserIDFromCtx(c)
+	list, err := a.friendService.List(c, userId)
+	response.Dynamic(c.Writer, list, err)
+}
+
+// Delete 删除好友
+func (a *FriendApp) Delete(c *gin.Context) {
+	var uri friend.FriendIdUri
+	if err := c.ShouldBindUri(&uri); err != nil {
+		util.HandleValidatorError(c, err)
+		return
+	}
+
+	userId, _ := context.UserIDFromCtx(c)
+	response.Dynamic(c.Writer, nil, a.friendService.Delete(c, userId, uri.Id))
+}
+
+// SendRequest 发送好友申请
+func (a *FriendApp) SendRequest(c *gin.Context) {
+	var req friend.AddReq
+	if err := c.ShouldBind(&req); err != nil {
+		util.HandleValidatorError(c, err)
+		return
+	}
+
+	userId, _ := context.UserIDFromCtx(c)
+	result, err := a.friendService.SendRequest(c, userId, &req)
+	response.Dynamic(c.Writer, result, err)
+}
+
+// RequestList 收到的待处理好友申请
+func (a *FriendApp) RequestList(c *gin.Context) {
+	userId, _ := context.UserIDFromCtx(c)
+	list, err := a.friendService.RequestList(c, userId)
+	response.Dynamic(c.Writer, list, err)
+}
+
+// Accept 通过好友申请
+func (a *FriendApp) Accept(c *gin.Context) {
+	a.handleRequest(c, true)
+}
+
+// Reject 拒绝好友申请
+func (a *FriendApp) Reject(c *gin.Context) {
+	a.handleRequest(c, false)
+}
+
+// 处理好友申请
+func (a *FriendApp) handleRequest(c *gin.Context, accept bool) {
+	var uri friend.RequestIdUri
+	if err := c.ShouldBindUri(&uri); err != nil {
+		util.HandleValidatorError(c, err)
+		return
+	}
+
+	userId, _ := context.UserIDFromCtx(c)
+	result, err := a.friendService.HandleRequest(c, userId, uri.Id, accept)
+	response.Dynamic(c.Writer, result, err)
+}
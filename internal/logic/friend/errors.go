@@ -0,0 +1,16 @@
+package friend
+
+import "go-im/pkg/errorx"
+
+var (
+	ErrDBOperate        = errorx.New(80001, "数据库操作异常", "服务器繁忙，请稍后再试")
+	ErrAddSelf          = errorx.New(80002, "不能添加自己为好友", "不能添加自己为好友")
+	ErrUserNotFound     = errorx.New(80003, "用户不存在", "用户不存在")
+	ErrAlreadyFriend    = errorx.New(80004, "已经是好友，重复添加", "对方已经是您的好友")
+	ErrRequestPending   = errorx.New(80005, "好友申请待处理，重复申请", "已发送好友申请，请等待对方处理")
+	ErrRequestReceived  = errorx.New(80006, "对方已发送好友申请", "对方已向您发送好友申请，请直接处理")
+	ErrRequestNotFound  = errorx.New(80007, "好友申请不存在", "好友申请不存在")
+	ErrRequestProcessed = errorx.New(80008, "好友申请已处理", "该好友申请已被处理")
+	ErrNotFriend        = errorx.New(80009, "不是好友", "对方不是您的好友")
+	ErrBlocked          = errorx.New(80010, "已被对方屏蔽", "对方已拒绝接收您的好友申请")
+)
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Friend 好友关系（双向存储，每对好友两条记录）
+type Friend struct {
+	Id        uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT"`                 // 主键
+	UserId    uint64    `gorm:"column:user_id;NOT NULL"`                              // 用户id
+	FriendId  uint64    `gorm:"column:friend_id;NOT NULL"`                            // 好友id
+	CreatedAt time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 创建时间
+}
+
+func (m *Friend) TableName() string {
+	return "friend"
+}
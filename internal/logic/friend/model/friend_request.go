@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// 好友申请状态
+const (
+	RequestPending  uint8 = iota // 待处理
+	RequestAccepted              // 已通过
+	RequestRejected              // 已拒绝
+)
+
+type FriendRequest struct {
+	Id        uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT"`                 // 主键
+	FromUid   uint64    `gorm:"column:from_uid;NOT NULL"`                             // 申请人
+	ToUid     uint64    `gorm:"column:to_uid;NOT NULL"`                               // 被申请人
+	Remark    string    `gorm:"column:remark;NOT NULL"`                               // 申请说明
+	Status    uint8     `gorm:"column:status;NOT NULL"`                               // 状态：0 待处理 1 已通过 2 已拒绝
+	CreatedAt time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 申请时间
+	UpdatedAt time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 更新时间
+}
+
+func (m *FriendRequest) TableName() string {
+	return "friend_request"
+}
@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"context"
+	"go-im/internal/logic/friend"
+	"go-im/internal/logic/friend/model"
+	"go-im/pkg/mysql"
+	"go-im/pkg/util"
+	"gorm.io/gorm/clause"
+)
+
+func NewFriendRepo() *FriendRepo {
+	return &FriendRepo{
+		db: mysql.GetMysqlClient(mysql.DefaultClient),
+	}
+}
+
+type FriendRepo struct {
+	db *mysql.DB
+}
+
+// Transaction 事务操作，fc 中使用 txCtx 调用 repo 方法即可加入事务
+func (d *FriendRepo) Transaction(ctx context.Context, fc func(txCtx context.Context) error) error {
+	return mysql.Transaction(ctx, d.db.DB, fc)
+}
+
+// Add 添加好友（双向），已是好友时忽略
+func (d *FriendRepo) Add(ctx context.Context, userId, friendId uint64) error {
+	rows := []*model.Friend{
+		{UserId: userId, FriendId: friendId},
+		{UserId: friendId, FriendId: userId},
+	}
+	err := mysql.GetDB(ctx, d.db.DB).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return friend.ErrDBOperate
+	}
+	return nil
+}
+
+// Exists 是否好友
+func (d *FriendRepo) Exists(ctx context.Context, userId, friendId uint64) (bool, error) {
+	var count int64
+	err := mysql.GetDB(ctx, d.db.DB).Model(&model.Friend{}).
+		Where("user_id = ? AND friend_id = ?", userId, friendId).
+		Count(&count).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return false, friend.ErrDBOperate
+	}
+	return count > 0, nil
+}
+
+// List 获取用户的全部好友
+func (d *FriendRepo) List(ctx context.Context, userId uint64) ([]*model.Friend, error) {
+	var list []*model.Friend
+	err := mysql.GetDB(ctx, d.db.DB).
+		Where("user_id = ?", userId).
+		Order("id ASC").
+		Find(&list).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, friend.ErrDBOperate
+	}
+	return list, nil
+}
+
+// Remove 删除好友（双向），返回是否删除成功（不是好友时返回 false）
+func (d *FriendRepo) Remove(ctx context.Context, userId, friendId uint64) (bool, error) {
+	result := mysql.GetDB(ctx, d.db.DB).
+		Where("(user_id = ? AND friend_id = ?) OR (user_id = ? AND friend_id = ?)", userId, friendId, friendId, userId).
+		Delete(&model.Friend{})
+	if result.Error != nil {
+		util.LogError(ctx, result.Error)
+		return false, friend.ErrDBOperate
+	}
+	return result.RowsAffected > 0, nil
+}
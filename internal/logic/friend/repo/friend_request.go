@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"go-im/internal/logic/friend"
+	"go-im/internal/logic/friend/model"
+	"go-im/pkg/mysql"
+	"go-im/pkg/util"
+	"gorm.io/gorm"
+)
+
+func NewFriendRequestRepo() *FriendRequestRepo {
+	return &FriendRequestRepo{
+		db: mysql.GetMysqlClient(mysql.DefaultClient),
+	}
+}
+
+type FriendRequestRepo struct {
+	db *mysql.DB
+}
+
+// Add 保存好友申请
+func (d *FriendRequestRepo) Add(ctx context.Context, req *model.FriendRequest) error {
+	err := mysql.GetDB(ctx, d.db.DB).Create(req).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return friend.ErrDBOperate
+	}
+	return nil
+}
+
+// GetById 通过id获取好友申请
+func (d *FriendRequestRepo) GetById(ctx context.Context, id uint64) (*model.FriendRequest, error) {
+	var req model.FriendRequest
+	err := mysql.GetDB(ctx, d.db.DB).First(&req, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		util.LogError(ctx, err)
+		return nil, friend.ErrDBOperate
+	}
+	return &req, nil
+}
+
+// HasPending 是否存在待处理的好友申请
+func (d *FriendRequestRepo) HasPending(ctx context.Context, fromUid, toUid uint64) (bool, error) {
+	var count int64
+	err := mysql.GetDB(ctx, d.db.DB).Model(&model.FriendRequest{}).
+		Where("from_uid = ? AND to_uid = ? AND status = ?", fromUid, toUid, model.RequestPending).
+		Count(&count).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return false, friend.ErrDBOperate
+	}
+	return count > 0, nil
+}
+
+// ListPending 获取用户收到的待处理好友申请
+func (d *FriendRequestRepo) ListPending(ctx context.Context, toUid uint64) ([]*model.FriendRequest, error) {
+	var list []*model.FriendRequest
+	err := mysql.GetDB(ctx, d.db.DB).
+		Where("to_uid = ? AND status = ?", toUid, model.RequestPending).
+		Order("id DESC").
+		Find(&list).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, friend.ErrDBOperate
+	}
+	return list, nil
+}
+
+// Handle 处理好友申请，返回是否处理成功（申请已被处理时返回 false）
+func (d *FriendRequestRepo) Handle(ctx context.Context, id uint64, status uint8) (bool, error) {
+	result := mysql.GetDB(ctx, d.db.DB).Model(&model.FriendRequest{}).
+		Where("id = ? AND status = ?", id, model.RequestPending).
+		Update("status", status)
+	if result.Error != nil {
+		util.LogError(ctx, result.Error)
+		return false, friend.ErrDBOperate
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"go-im/internal/connect"
+	"go-im/internal/logic/friend"
+	"go-im/internal/logic/friend/model"
+	"go-im/internal/logic/friend/repo"
+	roomRepo "go-im/internal/logic/room/repo"
+	"go-im/internal/logic/room/types"
+	userModel "go-im/internal/logic/user/model"
+	userRepo "go-im/internal/logic/user/repo"
+	"time"
+)
+
+var _ IService = (*Service)(nil)
+
+type IService interface {
+	// 发送好友申请
+	SendRequest(ctx context.Context, userId uint64, req *friend.AddReq) (*friend.RequestItem, error)
+	// 收到的待处理好友申请
+	RequestList(ctx context.Context, userId uint64) ([]*friend.RequestItem, error)
+	// 处理好友申请
+	HandleRequest(ctx context.Context, userId, requestId uint64, accept bool) (*friend.RequestItem, error)
+	// 好友列表
+	List(ctx context.Context, userId uint64) ([]*friend.FriendItem, error)
+	// 删除好友
+	Delete(ctx context.Context, userId, friendId uint64) error
+}
+
+func NewFriendService() IService {
+	return &Service{
+		friendRepo:      repo.NewFriendRepo(),
+		requestRepo:     repo.NewFriendRequestRepo(),
+		userRepo:        userRepo.NewUserRepo(),
+		userBlockRepo:   userRepo.NewUserBlockRepo(),
+		userOnlineCache: roomRepo.NewUserOnlineCache(),
+	}
+}
+
+type Service struct {
+	friendRepo      *repo.FriendRepo
+	requestRepo     *repo.FriendRequestRepo
+	userRepo        *userRepo.UserRepo
+	userBlockRepo   *userRepo.UserBlockRepo
+	userOnlineCache *roomRepo.UserOnlineCache
+}
+
+// SendRequest 发送好友申请，并通知对方
+func (s *Service) SendRequest(ctx context.Context, userId uint64, req *friend.AddReq) (*friend.RequestItem, error) {
+	if req.UserId == userId {
+		return nil, friend.ErrAddSelf
+	}
+
+	target, err := s.userRepo.GetById(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, friend.ErrUserNotFound
+	}
+
+	// 对方已屏蔽申请人
+	blocked, err := s.userBlockRepo.Exists(ctx, req.UserId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, friend.ErrBlocked
+	}
+
+	isFriend, err := s.friendRepo.Exists(ctx, userId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if isFriend {
+		return nil, friend.ErrAlreadyFriend
+	}
+
+	// 已申请或对方已申请
+	if pending, err := s.requestRepo.HasPending(ctx, userId, req.UserId); err != nil {
+		return nil, err
+	} else if pending {
+		return nil, friend.ErrRequestPending
+	}
+	if pending, err := s.requestRepo.HasPending(ctx, req.UserId, userId); err != nil {
+		return nil, err
+	} else if pending {
+		return nil, friend.ErrRequestReceived
+	}
+
+	request := &model.FriendRequest{
+		FromUid: userId,
+		ToUid:   req.UserId,
+		Remark:  req.Remark,
+		Status:  model.RequestPending,
+	}
+	if err = s.requestRepo.Add(ctx, request); err != nil {
+		return nil, err
+	}
+
+	item := s.requestItem(ctx, request)
+	notify(req.UserId, friend.Event{Action: friend.ActionRequest, UserId: userId, Request: item})
+	return item, nil
+}
+
+// RequestList 收到的待处理好友申请
+func (s *Service) RequestList(ctx context.Context, userId uint64) ([]*friend.RequestItem, error) {
+	list, err := s.requestRepo.ListPending(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	fromIds := make([]uint64, 0, len(list))
+	for _, request := range list {
+		fromIds = append(fromIds, request.FromUid)
+	}
+	users, err := s.userMap(ctx, fromIds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*friend.RequestItem, 0, len(list))
+	for _, request := range list {
+		result = append(result, newRequestItem(request, users[request.FromUid]))
+	}
+	return result, nil
+}
+
+// HandleRequest 处理好友申请（只能处理发送给自己的申请），通过后双方成为好友，并通知申请人
+func (s *Service) HandleRequest(ctx context.Context, userId, requestId uint64, accept bool) (*friend.RequestItem, error) {
+	request, err := s.requestRepo.GetById(ctx, requestId)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || request.ToUid != userId {
+		return nil, friend.ErrRequestNotFound
+	}
+
+	status := model.RequestRejected
+	if accept {
+		status = model.RequestAccepted
+	}
+	err = s.friendRepo.Transaction(ctx, func(txCtx context.Context) error {
+		ok, err := s.requestRepo.Handle(txCtx, request.Id, status)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return friend.ErrRequestProcessed
+		}
+		if accept {
+			return s.friendRepo.Add(txCtx, request.FromUid, request.ToUid)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	request.Status = status
+
+	item := s.requestItem(ctx, request)
+	action := friend.ActionReject
+	if accept {
+		action = friend.ActionAccept
+	}
+	notify(request.FromUid, friend.Event{Action: action, UserId: userId, Request: item})
+	return item, nil
+}
+
+// List 好友列表（包含在线状态）
+func (s *Service) List(ctx context.Context, userId uint64) ([]*friend.FriendItem, error) {
+	list, err := s.friendRepo.List(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	friendIds := make([]uint64, 0, len(list))
+	for _, item := range list {
+		friendIds = append(friendIds, item.FriendId)
+	}
+	users, err := s.userMap(ctx, friendIds)
+	if err != nil {
+		return nil, err
+	}
+	online := s.userOnlineCache.OnlineMap(friendIds)
+
+	result := make([]*friend.FriendItem, 0, len(list))
+	for _, item := range list {
+		u, ok := users[item.FriendId]
+		if !ok { // 用户已不存在
+			continue
+		}
+		result = append(result, &friend.FriendItem{
+			Id:        item.FriendId,
+			Username:  u.Username,
+			Nickname:  u.Nickname,
+			Online:    online[item.FriendId],
+			CreatedAt: item.CreatedAt.Unix(),
+		})
+	}
+	return result, nil
+}
+
+// Delete 删除好友（双向删除），并通知对方
+func (s *Service) Delete(ctx context.Context, userId, friendId uint64) error {
+	ok, err := s.friendRepo.Remove(ctx, userId, friendId)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return friend.ErrNotFriend
+	}
+
+	notify(friendId, friend.Event{Action: friend.ActionDelete, UserId: userId})
+	return nil
+}
+
+// 好友申请信息
+func (s *Service) requestItem(ctx context.Context, request *model.FriendRequest) *friend.RequestItem {
+	from, _ := s.userRepo.GetById(ctx, request.FromUid)
+	return newRequestItem(request, from)
+}
+
+// 批量获取用户信息
+func (s *Service) userMap(ctx context.Context, ids []uint64) (map[uint64]*userModel.User, error) {
+	list, err := s.userRepo.ListByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint64]*userModel.User, len(list))
+	for _, u := range list {
+		result[u.Id] = u
+	}
+	return result, nil
+}
+
+func newRequestItem(request *model.FriendRequest, from *userModel.User) *friend.RequestItem {
+	item := &friend.RequestItem{
+		Id:        request.Id,
+		FromUid:   request.FromUid,
+		ToUid:     request.ToUid,
+		Remark:    request.Remark,
+		Status:    request.Status,
+		CreatedAt: request.CreatedAt.Unix(),
+	}
+	if from != nil {
+		item.FromName = from.Username
+		if from.Nickname != "" {
+			item.FromName = from.Nickname
+		}
+	}
+	return item
+}
+
+// 通过网关通知用户（用户连接所在的 IM 服务推送给用户）
+func notify(toUid uint64, event friend.Event) {
+	data := types.QueueMsgData{
+		Method:   types.MethodFriendNotice,
+		FromUid:  event.UserId,
+		ToUid:    toUid,
+		Data:     event,
+		SendTime: time.Now().UnixMilli(),
+	}
+	connect.SendGatewayMsg(data.Marshal())
+}
@@ -0,0 +1,49 @@
+package friend
+
+// 好友通知类型
+const (
+	ActionRequest = "request" // 收到好友申请
+	ActionAccept  = "accept"  // 好友申请已通过
+	ActionReject  = "reject"  // 好友申请被拒绝
+	ActionDelete  = "delete"  // 被删除好友
+)
+
+type AddReq struct {
+	UserId uint64 `binding:"required" form:"user_id" json:"user_id" xml:"user_id" label:"用户"`
+	Remark string `binding:"max=100" form:"remark" json:"remark" xml:"remark" label:"申请说明"`
+}
+
+type RequestIdUri struct {
+	Id uint64 `binding:"required" uri:"id" label:"好友申请"`
+}
+
+type FriendIdUri struct {
+	Id uint64 `binding:"required" uri:"id" label:"好友"`
+}
+
+// FriendItem 好友信息
+type FriendItem struct {
+	Id        uint64 `json:"id"`
+	Username  string `json:"username"`
+	Nickname  string `json:"nickname"`
+	Online    bool   `json:"online"`     // 是否在线
+	CreatedAt int64  `json:"created_at"` // 成为好友的时间（秒）
+}
+
+// RequestItem 好友申请
+type RequestItem struct {
+	Id        uint64 `json:"id"`
+	FromUid   uint64 `json:"from_uid"`
+	FromName  string `json:"from_name"`
+	ToUid     uint64 `json:"to_uid"`
+	Remark    string `json:"remark"`
+	Status    uint8  `json:"status"`     // 状态：0 待处理 1 已通过 2 已拒绝
+	CreatedAt int64  `json:"created_at"` // 申请时间（秒）
+}
+
+// Event 好友通知（通过 websocket 推送给对方）
+type Event struct {
+	Action  string       `json:"action"`
+	UserId  uint64       `json:"user_id"`           // 操作者
+	Request *RequestItem `json:"request,omitempty"` // 好友申请（申请、通过、拒绝时）
+}
@@ -0,0 +1,13 @@
+package message
+
+import "go-im/pkg/errorx"
+
+var (
+	ErrDBOperate   = errorx.New(50001, "数据库操作异常", "服务器繁忙，请稍后再试")
+	ErrContentJson = errorx.New(50002, "消息内容序列化失败", "消息格式有误")
+	ErrSeq         = errorx.New(50003, "生成消息序号失败", "服务器繁忙，请稍后再试")
+	ErrMsgNotFound = errorx.New(50004, "消息不存在或不属于当前会话", "消息不存在")
+	ErrMsgRecalled = errorx.New(50005, "消息已撤回", "消息已撤回")
+	ErrRecallLimit = errorx.New(50006, "超过允许撤回的时间", "消息发送时间过久，无法撤回")
+	ErrEditLimit   = errorx.New(50007, "超过允许编辑的时间", "消息发送时间过久，无法编辑")
+)
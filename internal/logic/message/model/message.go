@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// 消息类型
+const (
+	TypeGroup   uint8 = iota + 1 // 群聊消息
+	TypePrivate                  // 私聊消息
+)
+
+// 消息状态
+const (
+	StatusNormal   uint8 = iota // 正常
+	StatusEdited                // 已编辑
+	StatusRecalled              // 已撤回
+)
+
+type Message struct {
+	Id        uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT"`                 // 主键（服务端消息id）
+	MsgType   uint8     `gorm:"column:msg_type;NOT NULL"`                             // 消息类型：1 群聊 2 私聊
+	FromUid   uint64    `gorm:"column:from_uid;NOT NULL"`                             // 发送者
+	RoomId    uint64    `gorm:"column:room_id;NOT NULL"`                              // 房间id（群聊消息）
+	ToUid     uint64    `gorm:"column:to_uid;NOT NULL"`                               // 接收者（私聊消息）
+	Seq       uint64    `gorm:"column:seq;NOT NULL"`                                  // 会话内消息序号
+	RequestId string    `gorm:"column:request_id;NOT NULL"`                           // 客户端消息id
+	Content   string    `gorm:"column:content;NOT NULL"`                              // 消息内容（json）
+	Status    uint8     `gorm:"column:status;NOT NULL"`                               // 状态：0 正常 1 已编辑 2 已撤回
+	CreatedAt time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 更新时间
+}
+
+func (m *Message) TableName() string {
+	return "message"
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// MessageRead 用户在会话中的已读位置
+type MessageRead struct {
+	Id        uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT"`                 // 主键
+	UserId    uint64    `gorm:"column:user_id;NOT NULL"`                              // 用户id
+	RoomId    uint64    `gorm:"column:room_id;NOT NULL"`                              // 房间id（群聊）
+	PeerUid   uint64    `gorm:"column:peer_uid;NOT NULL"`                             // 对方用户id（私聊）
+	MsgId     uint64    `gorm:"column:msg_id;NOT NULL"`                               // 已读到的消息id
+	CreatedAt time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 更新时间
+}
+
+func (m *MessageRead) TableName() string {
+	return "message_read"
+}
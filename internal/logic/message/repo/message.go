@@ -0,0 +1,137 @@
+package repo
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"go-im/internal/logic/message"
+	"go-im/internal/logic/message/model"
+	"go-im/pkg/mysql"
+	"go-im/pkg/util"
+	"gorm.io/gorm"
+)
+
+func NewMessageRepo() *MessageRepo {
+	return &MessageRepo{
+		db: mysql.GetMysqlClient(mysql.DefaultClient),
+	}
+}
+
+type MessageRepo struct {
+	db *mysql.DB
+}
+
+// Transaction 事务操作，fc 中使用 txCtx 调用 repo 方法即可加入事务
+func (d *MessageRepo) Transaction(ctx context.Context, fc func(txCtx context.Context) error) error {
+	return mysql.Transaction(ctx, d.db.DB, fc)
+}
+
+// Add 保存消息
+func (d *MessageRepo) Add(ctx context.Context, msgModel *model.Message) error {
+	err := mysql.GetDB(ctx, d.db.DB).Create(msgModel).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return message.ErrDBOperate
+	}
+	return nil
+}
+
+// ListBefore 按游标倒序获取消息（id 小于 beforeId，beforeId 为 0 时从最新消息开始）
+func (d *MessageRepo) ListBefore(ctx context.Context, query *message.HistoryQuery) ([]*model.Message, error) {
+	db := mysql.GetDB(ctx, d.db.DB).Model(&model.Message{}).Scopes(conversationScope(&query.Conversation))
+
+	if query.BeforeId > 0 {
+		db = db.Where("id < ?", query.BeforeId)
+	}
+
+	var list []*model.Message
+	if err := db.Order("id DESC").Limit(query.Limit).Find(&list).Error; err != nil {
+		util.LogError(ctx, err)
+		return nil, message.ErrDBOperate
+	}
+	return list, nil
+}
+
+// ListBySeq 按序号区间正序获取消息
+func (d *MessageRepo) ListBySeq(ctx context.Context, query *message.SeqRangeQuery) ([]*model.Message, error) {
+	db := mysql.GetDB(ctx, d.db.DB).Model(&model.Message{}).
+		Scopes(conversationScope(&query.Conversation)).
+		Where("seq >= ?", query.FromSeq)
+
+	if query.ToSeq > 0 {
+		db = db.Where("seq <= ?", query.ToSeq)
+	}
+
+	var list []*model.Message
+	err := db.Order("seq ASC").Limit(query.Limit).Find(&list).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, message.ErrDBOperate
+	}
+	return list, nil
+}
+
+// MaxSeq 获取会话当前最大序号
+func (d *MessageRepo) MaxSeq(ctx context.Context, conv *message.Conversation) (uint64, error) {
+	var seq uint64
+	err := mysql.GetDB(ctx, d.db.DB).Model(&model.Message{}).
+		Scopes(conversationScope(conv)).
+		Select("COALESCE(MAX(seq), 0)").
+		Scan(&seq).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return 0, message.ErrDBOperate
+	}
+	return seq, nil
+}
+
+// 会话查询条件
+func conversationScope(conv *message.Conversation) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if conv.RoomId > 0 {
+			return db.Where("msg_type = ? AND room_id = ?", model.TypeGroup, conv.RoomId)
+		}
+		return db.Where("msg_type = ? AND ((from_uid = ? AND to_uid = ?) OR (from_uid = ? AND to_uid = ?))",
+			model.TypePrivate, conv.UserId, conv.PeerUid, conv.PeerUid, conv.UserId)
+	}
+}
+
+// GetById 通过id获取消息
+func (d *MessageRepo) GetById(ctx context.Context, id uint64) (*model.Message, error) {
+	var msgModel model.Message
+	err := mysql.GetDB(ctx, d.db.DB).First(&msgModel, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		util.LogError(ctx, err)
+		return nil, message.ErrDBOperate
+	}
+	return &msgModel, nil
+}
+
+// Recall 撤回消息（保留消息内容，用于审计）
+func (d *MessageRepo) Recall(ctx context.Context, id uint64) (bool, error) {
+	result := mysql.GetDB(ctx, d.db.DB).Model(&model.Message{}).
+		Where("id = ? AND status != ?", id, model.StatusRecalled).
+		Update("status", model.StatusRecalled)
+	if result.Error != nil {
+		util.LogError(ctx, result.Error)
+		return false, message.ErrDBOperate
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateContent 编辑消息内容
+func (d *MessageRepo) UpdateContent(ctx context.Context, id uint64, content string) (bool, error) {
+	result := mysql.GetDB(ctx, d.db.DB).Model(&model.Message{}).
+		Where("id = ? AND status != ?", id, model.StatusRecalled).
+		Updates(map[string]any{
+			"content": content,
+			"status":  model.StatusEdited,
+		})
+	if result.Error != nil {
+		util.LogError(ctx, result.Error)
+		return false, message.ErrDBOperate
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"context"
+	"go-im/internal/logic/message"
+	"go-im/internal/logic/message/model"
+	"go-im/pkg/mysql"
+	"go-im/pkg/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewMessageReadRepo() *MessageReadRepo {
+	return &MessageReadRepo{
+		db: mysql.GetMysqlClient(mysql.DefaultClient),
+	}
+}
+
+type MessageReadRepo struct {
+	db *mysql.DB
+}
+
+// Save 保存已读位置（只会向后移动）
+func (d *MessageReadRepo) Save(ctx context.Context, readModel *model.MessageRead) error {
+	err := mysql.GetDB(ctx, d.db.DB).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"msg_id": gorm.Expr("GREATEST(msg_id, VALUES(msg_id))"),
+		}),
+	}).Create(readModel).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return message.ErrDBOperate
+	}
+	return nil
+}
+
+// RoomUnread 获取房间未读消息数量（只统计已加入的房间，没有已读记录时全部消息都是未读，不包含自己发送的消息）
+func (d *MessageReadRepo) RoomUnread(ctx context.Context, userId uint64, roomIds []uint64) (map[uint64]int64, error) {
+	var result = make(map[uint64]int64, len(roomIds))
+	if len(roomIds) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		RoomId uint64
+		Unread int64
+	}
+	err := mysql.GetDB(ctx, d.db.DB).Table("message AS m").
+		Select("m.room_id, COUNT(*) AS unread").
+		Joins("INNER JOIN room_member AS rm ON rm.room_id = m.room_id AND rm.user_id = ?", userId).
+		Joins("LEFT JOIN message_read AS r ON r.user_id = ? AND r.room_id = m.room_id AND r.peer_uid = 0", userId).
+		Where("m.msg_type = ? AND m.room_id IN ? AND m.from_uid != ? AND m.id > COALESCE(r.msg_id, 0)", model.TypeGroup, roomIds, userId).
+		Group("m.room_id").
+		Scan(&rows).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, message.ErrDBOperate
+	}
+
+	for _, row := range rows {
+		result[row.RoomId] = row.Unread
+	}
+	return result, nil
+}
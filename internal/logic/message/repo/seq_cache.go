@@ -0,0 +1,70 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"go-im/internal/logic/message"
+	"go-im/pkg/logger"
+	pkgRedis "go-im/pkg/redis"
+	"go.uber.org/zap"
+)
+
+/**
+ * @Description: 会话消息序号（每个房间、每对私聊用户单独递增）
+ */
+
+const cacheKeyMsgSeq = "msg_seq:"
+
+func NewSeqCache() *SeqCache {
+	return &SeqCache{
+		rdClient: pkgRedis.C(pkgRedis.NAME_DEFAULT),
+	}
+}
+
+type SeqCache struct {
+	rdClient *redis.Client
+}
+
+// Next 获取会话下一个序号。返回 0 表示序号缓存不存在，需要调用 Init 初始化
+func (r *SeqCache) Next(conv *message.Conversation) (uint64, error) {
+	script := `
+	if( redis.call("EXISTS", KEYS[1]) == 1 ) then
+		return redis.call("INCR", KEYS[1])
+	end
+	return 0
+`
+	seq, err := redis.NewScript(script).Run(context.Background(), r.rdClient, []string{r.cKey(conv)}).Uint64()
+	if err != nil {
+		logger.Error("msg seq incr lua script error", zap.Error(err))
+		return 0, message.ErrSeq
+	}
+	return seq, nil
+}
+
+// Init 使用当前最大序号初始化（缓存已存在时不覆盖），并返回下一个序号
+func (r *SeqCache) Init(conv *message.Conversation, maxSeq uint64) (uint64, error) {
+	script := `
+	redis.call("SETNX", KEYS[1], ARGV[1])
+	return redis.call("INCR", KEYS[1])
+`
+	seq, err := redis.NewScript(script).Run(context.Background(), r.rdClient, []string{r.cKey(conv)}, maxSeq).Uint64()
+	if err != nil {
+		logger.Error("msg seq init lua script error", zap.Error(err))
+		return 0, message.ErrSeq
+	}
+	return seq, nil
+}
+
+// 缓存key（私聊按用户id从小到大拼接，保证双方使用同一个序号）
+func (r *SeqCache) cKey(conv *message.Conversation) string {
+	if conv.RoomId > 0 {
+		return fmt.Sprintf("%sroom:%d", cacheKeyMsgSeq, conv.RoomId)
+	}
+
+	small, big := conv.UserId, conv.PeerUid
+	if small > big {
+		small, big = big, small
+	}
+	return fmt.Sprintf("%sprivate:%d_%d", cacheKeyMsgSeq, small, big)
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-im/config"
+	"go-im/internal/logic/message"
+	"go-im/internal/logic/message/model"
+	"go-im/internal/logic/message/repo"
+	"go-im/pkg/logger"
+	"go.uber.org/zap"
+	"time"
+)
+
+var _ IService = (*Service)(nil)
+
+type IService interface {
+	// 保存群聊消息
+	SaveGroupMsg(ctx context.Context, fromUid, roomId uint64, requestId string, content any) (*model.Message, error)
+	// 保存私聊消息
+	SavePrivateMsg(ctx context.Context, fromUid, toUid uint64, requestId string, content any) (*model.Message, error)
+	// 历史消息，返回结果按发送时间正序排列，以及是否还有更早的消息
+	History(ctx context.Context, query *message.HistoryQuery) ([]*model.Message, bool, error)
+	// 按序号区间同步消息，返回结果按序号正序排列，以及区间是否被截断
+	SyncBySeq(ctx context.Context, query *message.SeqRangeQuery) ([]*model.Message, bool, error)
+	// 标记会话已读到指定消息，返回该消息
+	MarkRead(ctx context.Context, conv *message.Conversation, msgId uint64) (*model.Message, error)
+	// 获取房间未读消息数量
+	RoomUnread(ctx context.Context, userId uint64, roomIds []uint64) map[uint64]int64
+	// 获取消息
+	GetMsg(ctx context.Context, msgId uint64) (*model.Message, error)
+	// 撤回消息（需在允许撤回的时间内）
+	Recall(ctx context.Context, msg *model.Message) error
+	// 编辑消息（需在允许编辑的时间内）
+	Edit(ctx context.Context, msg *model.Message, content any) error
+}
+
+func NewMessageService() IService {
+	return &Service{
+		msgRepo:  repo.NewMessageRepo(),
+		readRepo: repo.NewMessageReadRepo(),
+		seqCache: repo.NewSeqCache(),
+	}
+}
+
+type Service struct {
+	msgRepo  *repo.MessageRepo
+	readRepo *repo.MessageReadRepo
+	seqCache *repo.SeqCache
+}
+
+// SaveGroupMsg 保存群聊消息
+func (s *Service) SaveGroupMsg(ctx context.Context, fromUid, roomId uint64, requestId string, content any) (*model.Message, error) {
+	return s.save(ctx, &model.Message{
+		MsgType:   model.TypeGroup,
+		FromUid:   fromUid,
+		RoomId:    roomId,
+		RequestId: requestId,
+	}, content)
+}
+
+// SavePrivateMsg 保存私聊消息
+func (s *Service) SavePrivateMsg(ctx context.Context, fromUid, toUid uint64, requestId string, content any) (*model.Message, error) {
+	return s.save(ctx, &model.Message{
+		MsgType:   model.TypePrivate,
+		FromUid:   fromUid,
+		ToUid:     toUid,
+		RequestId: requestId,
+	}, content)
+}
+
+// 保存消息（消息内容统一以 json 格式存储，写入失败时已分配的序号不会回收）
+func (s *Service) save(ctx context.Context, msg *model.Message, content any) (*model.Message, error) {
+	contentJson, err := json.Marshal(content)
+	if err != nil {
+		logger.Error("message content marshal error", zap.Error(err))
+		return nil, message.ErrContentJson
+	}
+	msg.Content = string(contentJson)
+
+	if msg.Seq, err = s.nextSeq(ctx, &message.Conversation{
+		UserId:  msg.FromUid,
+		RoomId:  msg.RoomId,
+		PeerUid: msg.ToUid,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err = s.msgRepo.Add(ctx, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// History 历史消息
+func (s *Service) History(ctx context.Context, query *message.HistoryQuery) ([]*model.Message, bool, error) {
+	if query.Limit <= 0 {
+		query.Limit = message.HistoryDefaultLimit
+	}
+	if query.Limit > message.HistoryMaxLimit {
+		query.Limit = message.HistoryMaxLimit
+	}
+
+	// 多查询一条，用于判断是否还有更早的消息
+	limit := query.Limit
+	query.Limit++
+	list, err := s.msgRepo.ListBefore(ctx, query)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(list) > limit
+	if hasMore {
+		list = list[:limit]
+	}
+
+	// 倒序查询结果转换为正序
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return list, hasMore, nil
+}
+
+// SyncBySeq 按序号区间同步消息，单次最多同步 HistoryMaxLimit 条，返回区间内是否还有更多消息。
+// 序号在消息写入数据库前分配，写入失败时该序号会永久空缺，因此序号不连续不代表消息缺失
+func (s *Service) SyncBySeq(ctx context.Context, query *message.SeqRangeQuery) ([]*model.Message, bool, error) {
+	if query.FromSeq == 0 {
+		query.FromSeq = 1
+	}
+	if query.ToSeq < query.FromSeq {
+		query.ToSeq = 0
+	}
+
+	// 多查询一条，用于判断是否被截断
+	query.Limit = message.HistoryMaxLimit + 1
+	list, err := s.msgRepo.ListBySeq(ctx, query)
+	if err != nil {
+		return nil, false, err
+	}
+
+	truncated := len(list) > message.HistoryMaxLimit
+	if truncated {
+		list = list[:message.HistoryMaxLimit]
+	}
+	return list, truncated, nil
+}
+
+// 获取会话下一个消息序号（缓存丢失时，使用数据库中的最大序号恢复）
+func (s *Service) nextSeq(ctx context.Context, conv *message.Conversation) (uint64, error) {
+	seq, err := s.seqCache.Next(conv)
+	if err != nil || seq > 0 {
+		return seq, err
+	}
+
+	maxSeq, err := s.msgRepo.MaxSeq(ctx, conv)
+	if err != nil {
+		return 0, err
+	}
+	return s.seqCache.Init(conv, maxSeq)
+}
+
+// MarkRead 标记会话已读到指定消息
+func (s *Service) MarkRead(ctx context.Context, conv *message.Conversation, msgId uint64) (*model.Message, error) {
+	msg, err := s.msgRepo.GetById(ctx, msgId)
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil || !s.inConversation(conv, msg) {
+		return nil, message.ErrMsgNotFound
+	}
+
+	if err = s.readRepo.Save(ctx, &model.MessageRead{
+		UserId:  conv.UserId,
+		RoomId:  conv.RoomId,
+		PeerUid: conv.PeerUid,
+		MsgId:   msgId,
+	}); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// RoomUnread 获取房间未读消息数量（查询失败时返回空结果，不影响房间列表）
+func (s *Service) RoomUnread(ctx context.Context, userId uint64, roomIds []uint64) map[uint64]int64 {
+	result, err := s.readRepo.RoomUnread(ctx, userId, roomIds)
+	if err != nil {
+		return map[uint64]int64{}
+	}
+	return result
+}
+
+// 消息是否属于会话
+func (s *Service) inConversation(conv *message.Conversation, msg *model.Message) bool {
+	if conv.RoomId > 0 {
+		return msg.MsgType == model.TypeGroup && msg.RoomId == conv.RoomId
+	}
+
+	return msg.MsgType == model.TypePrivate &&
+		((msg.FromUid == conv.UserId && msg.ToUid == conv.PeerUid) || (msg.FromUid == conv.PeerUid && msg.ToUid == conv.UserId))
+}
+
+// GetMsg 获取消息
+func (s *Service) GetMsg(ctx context.Context, msgId uint64) (*model.Message, error) {
+	msg, err := s.msgRepo.GetById(ctx, msgId)
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil {
+		return nil, message.ErrMsgNotFound
+	}
+	return msg, nil
+}
+
+// Recall 撤回消息
+func (s *Service) Recall(ctx context.Context, msg *model.Message) error {
+	if msg.Status == model.StatusRecalled {
+		return message.ErrMsgRecalled
+	}
+	if s.isExpired(msg, config.C.Message.RecallWindow) {
+		return message.ErrRecallLimit
+	}
+
+	ok, err := s.msgRepo.Recall(ctx, msg.Id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return message.ErrMsgRecalled
+	}
+
+	msg.Status = model.StatusRecalled
+	return nil
+}
+
+// Edit 编辑消息
+func (s *Service) Edit(ctx context.Context, msg *model.Message, content any) error {
+	if msg.Status == model.StatusRecalled {
+		return message.ErrMsgRecalled
+	}
+	if s.isExpired(msg, config.C.Message.EditWindow) {
+		return message.ErrEditLimit
+	}
+
+	contentJson, err := json.Marshal(content)
+	if err != nil {
+		logger.Error("message content marshal error", zap.Error(err))
+		return message.ErrContentJson
+	}
+
+	ok, err := s.msgRepo.UpdateContent(ctx, msg.Id, string(contentJson))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return message.ErrMsgRecalled
+	}
+
+	msg.Content = string(contentJson)
+	msg.Status = model.StatusEdited
+	return nil
+}
+
+// 消息是否超过允许修改的时间（window 单位：秒）
+func (s *Service) isExpired(msg *model.Message, window int64) bool {
+	return time.Since(msg.CreatedAt) > time.Duration(window)*time.Second
+}
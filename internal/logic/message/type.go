@@ -0,0 +1,28 @@
+package message
+
+const (
+	HistoryDefaultLimit = 20  // 历史消息默认拉取数量
+	HistoryMaxLimit     = 100 // 历史消息最大拉取数量
+)
+
+// Conversation 会话（RoomId 与 PeerUid 二选一）
+type Conversation struct {
+	UserId  uint64 // 当前用户
+	RoomId  uint64 // 房间id
+	PeerUid uint64 // 私聊对方用户id
+}
+
+// HistoryQuery 历史消息查询条件
+type HistoryQuery struct {
+	Conversation
+	BeforeId uint64 // 游标，查询该消息id之前的消息
+	Limit    int    // 查询数量
+}
+
+// SeqRangeQuery 按序号区间查询消息
+type SeqRangeQuery struct {
+	Conversation
+	FromSeq uint64 // 起始序号（包含）
+	ToSeq   uint64 // 结束序号（包含），为 0 时不限制
+	Limit   int    // 最大数量
+}
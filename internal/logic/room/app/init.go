@@ -5,10 +5,15 @@ import (
 	"go-im/internal/logic/room/service"
 )
 
-func Init() {
+func Init(serverId string) {
 	srv := service.NewService()
+	// 服务心跳，用于识别已退出服务遗留的在线状态
+	srv.KeepAlive(serverId)
+	// 旧版本房间只保存在 Redis 中，启动时写入数据库
+	srv.MigrateLegacyRooms()
 
 	// 注册事件
+	event.RoomEvent.SubscribeAsync(event.OpenConn, srv.Open)
 	event.RoomEvent.SubscribeAsync(event.ReadMsg, srv.Dispatch)
 	event.RoomEvent.SubscribeAsync(event.GatewayMsg, srv.GatewayMsg)
 	event.RoomEvent.SubscribeAsync(event.CloseConn, srv.Close)
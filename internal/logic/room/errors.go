@@ -0,0 +1,7 @@
+package room
+
+import "go-im/pkg/errorx"
+
+var (
+	ErrDBOperate = errorx.New(70001, "数据库操作异常", "服务器繁忙，请稍后再试")
+)
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// 房间可见性
+const (
+	VisibilityPublic  uint8 = iota + 1 // 公开
+	VisibilityPrivate                  // 私有（不在房间列表中显示，需要邀请码加入）
+)
+
+// 房间状态
+const (
+	StatusNormal uint8 = iota + 1 // 正常
+	StatusClosed                  // 已关闭
+)
+
+type Room struct {
+	Id           uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT"`                 // 房间id
+	OwnerId      uint64    `gorm:"column:owner_id;NOT NULL"`                             // 创建者
+	Name         string    `gorm:"column:name;NOT NULL"`                                 // 房间名称
+	Avatar       string    `gorm:"column:avatar;NOT NULL"`                               // 房间头像
+	Description  string    `gorm:"column:description;NOT NULL"`                          // 房间简介
+	Announcement string    `gorm:"column:announcement;NOT NULL"`                         // 房间公告
+	Visibility   uint8     `gorm:"column:visibility;NOT NULL"`                           // 可见性：1 公开 2 私有
+	JoinApproval bool      `gorm:"column:join_approval;NOT NULL"`                        // 加入是否需要审核
+	MaxMembers   int64     `gorm:"column:max_members;NOT NULL"`                          // 成员上限，0 表示使用默认上限
+	Settings     string    `gorm:"column:settings;NOT NULL"`                             // 房间设置（json）
+	Status       uint8     `gorm:"column:status;NOT NULL"`                               // 状态：1 正常 2 已关闭
+	CreatedAt    time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 创建时间
+	UpdatedAt    time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 更新时间
+}
+
+func (m *Room) TableName() string {
+	return "room"
+}
+
+// IsPrivate 是否私有房间
+func (m *Room) IsPrivate() bool {
+	return m.Visibility == VisibilityPrivate
+}
+
+// IsNormal 房间是否正常（未关闭）
+func (m *Room) IsNormal() bool {
+	return m.Status == StatusNormal
+}
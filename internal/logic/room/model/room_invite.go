@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type RoomInvite struct {
+	Id        uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT"`                 // 主键
+	RoomId    uint64    `gorm:"column:room_id;NOT NULL"`                              // 房间id
+	Code      string    `gorm:"column:code;NOT NULL"`                                 // 邀请码
+	CreatorId uint64    `gorm:"column:creator_id;NOT NULL"`                           // 创建者
+	MaxUses   int       `gorm:"column:max_uses;NOT NULL"`                             // 最多使用次数，0 表示不限制
+	UsedCount int       `gorm:"column:used_count;NOT NULL"`                           // 已使用次数
+	ExpireAt  int64     `gorm:"column:expire_at;NOT NULL"`                            // 过期时间（秒级时间戳），0 表示永久有效
+	CreatedAt time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 创建时间
+}
+
+func (m *RoomInvite) TableName() string {
+	return "room_invite"
+}
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// 加入申请状态
+const (
+	JoinRequestPending  uint8 = iota // 待审核
+	JoinRequestApproved              // 已通过
+	JoinRequestRejected              // 已拒绝
+)
+
+type RoomJoinRequest struct {
+	Id        uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT"`                 // 主键
+	RoomId    uint64    `gorm:"column:room_id;NOT NULL"`                              // 房间id
+	UserId    uint64    `gorm:"column:user_id;NOT NULL"`                              // 申请人
+	Remark    string    `gorm:"column:remark;NOT NULL"`                               // 申请说明
+	Status    uint8     `gorm:"column:status;NOT NULL"`                               // 状态：0 待审核 1 已通过 2 已拒绝
+	HandlerId uint64    `gorm:"column:handler_id;NOT NULL"`                           // 审核人
+	CreatedAt time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 申请时间
+	UpdatedAt time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 更新时间
+}
+
+func (m *RoomJoinRequest) TableName() string {
+	return "room_join_request"
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type RoomMember struct {
+	Id        uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT"`                 // 主键
+	RoomId    uint64    `gorm:"column:room_id;NOT NULL"`                              // 房间id
+	UserId    uint64    `gorm:"column:user_id;NOT NULL"`                              // 用户id
+	Role      uint8     `gorm:"column:role;default:0;NOT NULL"`                       // 角色：0普通成员 1管理员（创建者记录在房间表中）
+	CreatedAt time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 加入时间
+}
+
+func (m *RoomMember) TableName() string {
+	return "room_member"
+}
@@ -3,4 +3,11 @@ package repo
 const (
 	cacheKeyCreateRoomId = "create_room_id" // 已创建的房间id
 	cacheKeyUserService  = "user_service"   // 用户与 serviceId 映射
+	cacheKeyUserOnline   = "user_online"    // 在线用户与 serviceId 映射
+	cacheKeyServerAlive  = "server_alive:"  // 存活的 IM service（心跳）
+	cacheKeyOfflineMsg   = "offline_msg:"   // 用户离线消息
+	cacheKeyRequestId    = "request_id:"    // 客户端消息id（消息去重）
+	cacheKeyRoomRole     = "room_role:"     // 房间成员角色
+	cacheKeyRoomBan      = "room_ban:"      // 禁止加入房间的用户
+	cacheKeyRoomMute     = "room_mute:"     // 房间禁言用户
 )
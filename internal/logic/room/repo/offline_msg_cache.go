@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"go-im/pkg/logger"
+	pkgRedis "go-im/pkg/redis"
+	"go-im/pkg/util"
+	"go.uber.org/zap"
+	"time"
+)
+
+/**
+ * @Description: 用户离线消息收件箱（按投递顺序保存）
+ */
+
+func NewOfflineMsgCache() *OfflineMsgCache {
+	return &OfflineMsgCache{
+		rdClient: pkgRedis.C(pkgRedis.NAME_DEFAULT),
+	}
+}
+
+type OfflineMsgCache struct {
+	rdClient *redis.Client
+}
+
+// Push 保存离线消息。超过 limit 时丢弃最早的消息，收件箱在 expire 时间内没有新消息时过期
+func (r *OfflineMsgCache) Push(userId uint64, data []byte, limit int64, expire time.Duration) bool {
+	key := r.cKey(userId)
+	pipe := r.rdClient.TxPipeline()
+	pipe.RPush(context.Background(), key, data)
+	if limit > 0 {
+		pipe.LTrim(context.Background(), key, -limit, -1)
+	}
+	if expire > 0 {
+		pipe.Expire(context.Background(), key, expire)
+	}
+
+	if _, err := pipe.Exec(context.Background()); err != nil {
+		logger.Error("push offline msg error", zap.Uint64("user_id", userId), zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// PopAll 取出并清空用户全部离线消息
+func (r *OfflineMsgCache) PopAll(userId uint64) [][]byte {
+	key := r.cKey(userId)
+	pipe := r.rdClient.TxPipeline()
+	listCmd := pipe.LRange(context.Background(), key, 0, -1)
+	pipe.Del(context.Background(), key)
+
+	if _, err := pipe.Exec(context.Background()); err != nil {
+		logger.Error("pop offline msg error", zap.Uint64("user_id", userId), zap.Error(err))
+		return nil
+	}
+
+	list := listCmd.Val()
+	result := make([][]byte, 0, len(list))
+	for _, item := range list {
+		result = append(result, []byte(item))
+	}
+	return result
+}
+
+// 缓存key
+func (r *OfflineMsgCache) cKey(userId uint64) string {
+	return cacheKeyOfflineMsg + util.Uint64ToString(userId)
+}
@@ -0,0 +1,58 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"go-im/pkg/logger"
+	pkgRedis "go-im/pkg/redis"
+	"go.uber.org/zap"
+	"time"
+)
+
+/**
+ * @Description: 客户端消息id（request_id）去重，保存消息的处理结果
+ */
+
+func NewRequestIdCache() *RequestIdCache {
+	return &RequestIdCache{
+		rdClient: pkgRedis.C(pkgRedis.NAME_DEFAULT),
+	}
+}
+
+type RequestIdCache struct {
+	rdClient *redis.Client
+}
+
+// Lock 标记请求处理中。返回 false 表示请求id已存在（重复请求）
+func (r *RequestIdCache) Lock(userId uint64, requestId string, expire time.Duration) bool {
+	ok, err := r.rdClient.SetNX(context.Background(), r.cKey(userId, requestId), "", expire).Result()
+	if err != nil {
+		// 缓存异常时不影响消息发送
+		logger.Error("lock request id error", zap.Error(err))
+		return true
+	}
+	return ok
+}
+
+// Get 获取请求的处理结果，返回空字符串表示请求处理中
+func (r *RequestIdCache) Get(userId uint64, requestId string) string {
+	return r.rdClient.Get(context.Background(), r.cKey(userId, requestId)).Val()
+}
+
+// Set 保存请求的处理结果
+func (r *RequestIdCache) Set(userId uint64, requestId string, result []byte, expire time.Duration) {
+	if err := r.rdClient.Set(context.Background(), r.cKey(userId, requestId), result, expire).Err(); err != nil {
+		logger.Error("set request id result error", zap.Error(err))
+	}
+}
+
+// Remove 删除请求id（请求处理失败时，允许客户端重试）
+func (r *RequestIdCache) Remove(userId uint64, requestId string) int64 {
+	return r.rdClient.Del(context.Background(), r.cKey(userId, requestId)).Val()
+}
+
+// 缓存key
+func (r *RequestIdCache) cKey(userId uint64, requestId string) string {
+	return fmt.Sprintf("%s%d:%s", cacheKeyRequestId, userId, requestId)
+}
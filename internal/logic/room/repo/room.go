@@ -0,0 +1,204 @@
+package repo
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"go-im/internal/logic/room"
+	"go-im/internal/logic/room/model"
+	"go-im/pkg/mysql"
+	"go-im/pkg/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewRoomRepo() *RoomRepo {
+	return &RoomRepo{
+		db: mysql.GetMysqlClient(mysql.DefaultClient),
+	}
+}
+
+type RoomRepo struct {
+	db *mysql.DB
+}
+
+// Transaction 事务操作，fc 中使用 txCtx 调用 repo 方法即可加入事务
+func (d *RoomRepo) Transaction(ctx context.Context, fc func(txCtx context.Context) error) error {
+	return mysql.Transaction(ctx, d.db.DB, fc)
+}
+
+// Add 创建房间
+func (d *RoomRepo) Add(ctx context.Context, roomModel *model.Room) error {
+	if roomModel.Settings == "" {
+		roomModel.Settings = "{}"
+	}
+	if roomModel.Status == 0 {
+		roomModel.Status = model.StatusNormal
+	}
+	if roomModel.Visibility == 0 {
+		roomModel.Visibility = model.VisibilityPublic
+	}
+
+	err := mysql.GetDB(ctx, d.db.DB).Create(roomModel).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return room.ErrDBOperate
+	}
+	return nil
+}
+
+// Restore 恢复指定id的房间，返回是否写入（房间id已存在时忽略，返回 false）
+func (d *RoomRepo) Restore(ctx context.Context, roomModel *model.Room) (bool, error) {
+	if roomModel.Settings == "" {
+		roomModel.Settings = "{}"
+	}
+	if roomModel.Status == 0 {
+		roomModel.Status = model.StatusNormal
+	}
+	if roomModel.Visibility == 0 {
+		roomModel.Visibility = model.VisibilityPublic
+	}
+
+	result := mysql.GetDB(ctx, d.db.DB).Clauses(clause.OnConflict{DoNothing: true}).Create(roomModel)
+	if result.Error != nil {
+		util.LogError(ctx, result.Error)
+		return false, room.ErrDBOperate
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetById 通过id获取房间（包含已关闭的房间）
+func (d *RoomRepo) GetById(ctx context.Context, id uint64) (*model.Room, error) {
+	var roomModel model.Room
+	err := mysql.GetDB(ctx, d.db.DB).First(&roomModel, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		util.LogError(ctx, err)
+		return nil, room.ErrDBOperate
+	}
+	return &roomModel, nil
+}
+
+// Close 关闭房间，返回是否关闭成功（房间不存在或已关闭时返回 false）
+func (d *RoomRepo) Close(ctx context.Context, id uint64) (bool, error) {
+	result := mysql.GetDB(ctx, d.db.DB).Model(&model.Room{}).
+		Where("id = ? AND status = ?", id, model.StatusNormal).
+		Update("status", model.StatusClosed)
+	if result.Error != nil {
+		util.LogError(ctx, result.Error)
+		return false, room.ErrDBOperate
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateOwner 转让房间，返回是否更新成功（创建者已变更时返回 false）
+func (d *RoomRepo) UpdateOwner(ctx context.Context, id, oldOwnerId, newOwnerId uint64) (bool, error) {
+	result := mysql.GetDB(ctx, d.db.DB).Model(&model.Room{}).
+		Where("id = ? AND owner_id = ? AND status = ?", id, oldOwnerId, model.StatusNormal).
+		Update("owner_id", newOwnerId)
+	if result.Error != nil {
+		util.LogError(ctx, result.Error)
+		return false, room.ErrDBOperate
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Update 更新房间信息，返回是否更新成功（房间不存在或已关闭时返回 false）
+func (d *RoomRepo) Update(ctx context.Context, id uint64, fields map[string]any) (bool, error) {
+	result := mysql.GetDB(ctx, d.db.DB).Model(&model.Room{}).
+		Where("id = ? AND status = ?", id, model.StatusNormal).
+		Updates(fields)
+	if result.Error != nil {
+		util.LogError(ctx, result.Error)
+		return false, room.ErrDBOperate
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Lock 锁定房间记录（需在事务中调用），同一房间的并发操作串行执行
+func (d *RoomRepo) Lock(ctx context.Context, id uint64) error {
+	var roomId uint64
+	err := mysql.GetDB(ctx, d.db.DB).Raw("SELECT id FROM `room` WHERE id = ? FOR UPDATE", id).Scan(&roomId).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return room.ErrDBOperate
+	}
+	return nil
+}
+
+// LockOwner 锁定创建者的用户记录（需在事务中调用），同一用户的并发创建房间串行执行
+func (d *RoomRepo) LockOwner(ctx context.Context, ownerId uint64) error {
+	var id uint64
+	err := mysql.GetDB(ctx, d.db.DB).Raw("SELECT id FROM `user` WHERE id = ? FOR UPDATE", ownerId).Scan(&id).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return room.ErrDBOperate
+	}
+	return nil
+}
+
+// CountByOwner 获取用户创建的正常状态的房间数量
+func (d *RoomRepo) CountByOwner(ctx context.Context, ownerId uint64) (int64, error) {
+	var count int64
+	err := mysql.GetDB(ctx, d.db.DB).Model(&model.Room{}).
+		Where("owner_id = ? AND status = ?", ownerId, model.StatusNormal).
+		Count(&count).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return 0, room.ErrDBOperate
+	}
+	return count, nil
+}
+
+// List 分页获取用户可见的房间列表（公开房间、用户创建及已加入的房间），返回房间列表及总数
+func (d *RoomRepo) List(ctx context.Context, query *room.ListQuery) ([]*model.Room, int64, error) {
+	db := mysql.GetDB(ctx, d.db.DB)
+	joined := db.Model(&model.RoomMember{}).Select("room_id").Where("user_id = ?", query.UserId)
+
+	tx := db.Model(&model.Room{}).Where("status = ?", model.StatusNormal)
+	if query.Joined {
+		tx = tx.Where("owner_id = ? OR id IN (?)", query.UserId, joined)
+	} else {
+		tx = tx.Where("visibility = ? OR owner_id = ? OR id IN (?)", model.VisibilityPublic, query.UserId, joined)
+	}
+	if query.Keyword != "" {
+		tx = tx.Where("name LIKE ?", "%"+util.EscapeLike(query.Keyword)+"%")
+	}
+	if query.OwnerId > 0 {
+		tx = tx.Where("owner_id = ?", query.OwnerId)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		util.LogError(ctx, err)
+		return nil, 0, room.ErrDBOperate
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	order := "ASC"
+	if query.Desc {
+		order = "DESC"
+	}
+	if query.Sort == room.SortMembers {
+		tx = tx.Order(clause.OrderBy{
+			Expression: clause.Expr{
+				SQL:                "(SELECT COUNT(*) FROM room_member WHERE room_member.room_id = room.id) " + order,
+				WithoutParentheses: true,
+			},
+		})
+	}
+
+	var list []*model.Room
+	err := tx.Order("id " + order).
+		Offset((query.Page - 1) * query.Size).
+		Limit(query.Size).
+		Find(&list).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, 0, room.ErrDBOperate
+	}
+	return list, total, nil
+}
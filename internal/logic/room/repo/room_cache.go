@@ -2,12 +2,10 @@ package repo
 
 import (
 	"context"
-	"github.com/pkg/errors"
+	"encoding/json"
 	"github.com/redis/go-redis/v9"
-	"go-im/pkg/logger"
 	pkgRedis "go-im/pkg/redis"
 	"go-im/pkg/util"
-	"go.uber.org/zap"
 )
 
 func NewRoomCache() *RoomCache {
@@ -18,40 +16,61 @@ type RoomCache struct {
 	rdClient *redis.Client
 }
 
+// RoomCacheItem 缓存的房间信息
+type RoomCacheItem struct {
+	Name         string `json:"name"`
+	OwnerId      uint64 `json:"owner_id"`
+	Private      bool   `json:"private"`
+	JoinApproval bool   `json:"join_approval"`
+	MaxMembers   int64  `json:"max_members"`
+}
+
 // IsCreate 判断房间是否创建
 func (r *RoomCache) IsCreate(roomId uint64) bool {
 	return r.rdClient.HExists(context.Background(), cacheKeyCreateRoomId, util.Uint64ToString(roomId)).Val()
 }
 
-// GetName 获取房间名称
-func (r *RoomCache) GetName(roomId uint64) string {
-	return r.rdClient.HGet(context.Background(), cacheKeyCreateRoomId, util.Uint64ToString(roomId)).Val()
-}
+// Get 获取房间信息，不存在或格式有误（旧版本只缓存了房间名称）时返回 nil
+func (r *RoomCache) Get(roomId uint64) *RoomCacheItem {
+	val := r.rdClient.HGet(context.Background(), cacheKeyCreateRoomId, util.Uint64ToString(roomId)).Val()
+	if val == "" {
+		return nil
+	}
 
-// 获取房间列表
-func (r *RoomCache) List() map[string]string {
-	return r.rdClient.HGetAll(context.Background(), cacheKeyCreateRoomId).Val()
+	var item RoomCacheItem
+	if err := json.Unmarshal([]byte(val), &item); err != nil || item.Name == "" {
+		return nil
+	}
+	return &item
 }
 
-// 创建房间。返回值：是否创建成功，0 表示房间已存在或创建失败
-func (r *RoomCache) Create(roomId uint64, roomName string) (bool, error) {
-	script := `
-	local value = redis.call("HEXISTS", KEYS[1], ARGV[1])
-		if( value == 0 ) then
-			return redis.call("HSET" , KEYS[1],ARGV[1],ARGV[2])
-		end
-		return 0
-`
-	result, err := redis.NewScript(script).Run(context.Background(), r.rdClient.Conn(), []string{cacheKeyCreateRoomId}, roomId, roomName).Result()
+// Legacy 获取旧版本格式的房间缓存（值只有房间名称），key 为房间id
+func (r *RoomCache) Legacy() (map[uint64]string, error) {
+	all, err := r.rdClient.HGetAll(context.Background(), cacheKeyCreateRoomId).Result()
 	if err != nil {
-		logger.Error("create room lua script error", zap.Error(err))
-		return false, errors.New("create room error")
+		return nil, err
 	}
-	ret := result.(int64)
-	if ret == 1 {
-		return true, nil
+
+	result := make(map[uint64]string)
+	for id, val := range all {
+		var item RoomCacheItem
+		if err = json.Unmarshal([]byte(val), &item); err == nil && item.Name != "" {
+			continue
+		}
+		if roomId, err := util.StringToUint64(id); err == nil && val != "" {
+			result[roomId] = val
+		}
+	}
+	return result, nil
+}
+
+// Set 缓存房间信息
+func (r *RoomCache) Set(roomId uint64, item *RoomCacheItem) error {
+	val, err := json.Marshal(item)
+	if err != nil {
+		return err
 	}
-	return false, nil
+	return r.rdClient.HSet(context.Background(), cacheKeyCreateRoomId, util.Uint64ToString(roomId), val).Err()
 }
 
 // 删除房间
@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"go-im/internal/logic/room"
+	"go-im/internal/logic/room/model"
+	"go-im/pkg/mysql"
+	"go-im/pkg/util"
+	"gorm.io/gorm"
+	"time"
+)
+
+func NewRoomInviteRepo() *RoomInviteRepo {
+	return &RoomInviteRepo{
+		db: mysql.GetMysqlClient(mysql.DefaultClient),
+	}
+}
+
+type RoomInviteRepo struct {
+	db *mysql.DB
+}
+
+// Add 创建邀请码
+func (d *RoomInviteRepo) Add(ctx context.Context, invite *model.RoomInvite) error {
+	err := mysql.GetDB(ctx, d.db.DB).Create(invite).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return room.ErrDBOperate
+	}
+	return nil
+}
+
+// GetByCode 通过邀请码获取邀请信息
+func (d *RoomInviteRepo) GetByCode(ctx context.Context, code string) (*model.RoomInvite, error) {
+	var invite model.RoomInvite
+	err := mysql.GetDB(ctx, d.db.DB).First(&invite, "code = ?", code).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		util.LogError(ctx, err)
+		return nil, room.ErrDBOperate
+	}
+	return &invite, nil
+}
+
+// Use 使用邀请码，返回是否使用成功（已过期或使用次数已达上限时返回 false）
+func (d *RoomInviteRepo) Use(ctx context.Context, id uint64) (bool, error) {
+	result := mysql.GetDB(ctx, d.db.DB).Model(&model.RoomInvite{}).
+		Where("id = ?", id).
+		Where("max_uses = 0 OR used_count < max_uses").
+		Where("expire_at = 0 OR expire_at > ?", time.Now().Unix()).
+		Update("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		util.LogError(ctx, result.Error)
+		return false, room.ErrDBOperate
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"go-im/internal/logic/room"
+	"go-im/internal/logic/room/model"
+	"go-im/pkg/mysql"
+	"go-im/pkg/util"
+	"gorm.io/gorm"
+)
+
+func NewRoomJoinRequestRepo() *RoomJoinRequestRepo {
+	return &RoomJoinRequestRepo{
+		db: mysql.GetMysqlClient(mysql.DefaultClient),
+	}
+}
+
+type RoomJoinRequestRepo struct {
+	db *mysql.DB
+}
+
+// Add 提交加入申请
+func (d *RoomJoinRequestRepo) Add(ctx context.Context, req *model.RoomJoinRequest) error {
+	err := mysql.GetDB(ctx, d.db.DB).Create(req).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return room.ErrDBOperate
+	}
+	return nil
+}
+
+// GetById 通过id获取加入申请
+func (d *RoomJoinRequestRepo) GetById(ctx context.Context, id uint64) (*model.RoomJoinRequest, error) {
+	var req model.RoomJoinRequest
+	err := mysql.GetDB(ctx, d.db.DB).First(&req, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		util.LogError(ctx, err)
+		return nil, room.ErrDBOperate
+	}
+	return &req, nil
+}
+
+// HasPending 用户是否有待审核的申请
+func (d *RoomJoinRequestRepo) HasPending(ctx context.Context, roomId, userId uint64) (bool, error) {
+	var count int64
+	err := mysql.GetDB(ctx, d.db.DB).Model(&model.RoomJoinRequest{}).
+		Where("room_id = ? AND user_id = ? AND status = ?", roomId, userId, model.JoinRequestPending).
+		Count(&count).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return false, room.ErrDBOperate
+	}
+	return count > 0, nil
+}
+
+// ListPending 获取房间待审核的申请
+func (d *RoomJoinRequestRepo) ListPending(ctx context.Context, roomId uint64) ([]*model.RoomJoinRequest, error) {
+	var list []*model.RoomJoinRequest
+	err := mysql.GetDB(ctx, d.db.DB).
+		Where("room_id = ? AND status = ?", roomId, model.JoinRequestPending).
+		Order("id ASC").
+		Find(&list).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, room.ErrDBOperate
+	}
+	return list, nil
+}
+
+// Handle 审核申请，返回是否审核成功（申请已被处理时返回 false）
+func (d *RoomJoinRequestRepo) Handle(ctx context.Context, id uint64, status uint8, handlerId uint64) (bool, error) {
+	result := mysql.GetDB(ctx, d.db.DB).Model(&model.RoomJoinRequest{}).
+		Where("id = ? AND status = ?", id, model.JoinRequestPending).
+		Updates(map[string]any{
+			"status":     status,
+			"handler_id": handlerId,
+		})
+	if result.Error != nil {
+		util.LogError(ctx, result.Error)
+		return false, room.ErrDBOperate
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,125 @@
+package repo
+
+import (
+	"context"
+	"go-im/internal/logic/room"
+	"go-im/internal/logic/room/model"
+	"go-im/internal/logic/room/types"
+	"go-im/pkg/mysql"
+	"go-im/pkg/util"
+	"gorm.io/gorm/clause"
+)
+
+/**
+ * @Description: 房间成员（持久化的成员关系，与在线用户缓存 RoomUserCache 不同，连接断开后仍然是房间成员）
+ */
+
+func NewRoomMemberRepo() *RoomMemberRepo {
+	return &RoomMemberRepo{
+		db: mysql.GetMysqlClient(mysql.DefaultClient),
+	}
+}
+
+type RoomMemberRepo struct {
+	db *mysql.DB
+}
+
+// Add 添加成员，已是成员时忽略
+func (d *RoomMemberRepo) Add(ctx context.Context, roomId, userId uint64) error {
+	err := mysql.GetDB(ctx, d.db.DB).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.RoomMember{RoomId: roomId, UserId: userId}).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return room.ErrDBOperate
+	}
+	return nil
+}
+
+// SetRole 设置成员角色（只更新已有的成员，不会添加成员）
+func (d *RoomMemberRepo) SetRole(ctx context.Context, roomId, userId uint64, role types.Role) error {
+	err := mysql.GetDB(ctx, d.db.DB).Model(&model.RoomMember{}).
+		Where("room_id = ? AND user_id = ?", roomId, userId).
+		Update("role", uint8(role)).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return room.ErrDBOperate
+	}
+	return nil
+}
+
+// Admins 获取房间全部管理员
+func (d *RoomMemberRepo) Admins(ctx context.Context, roomId uint64) ([]uint64, error) {
+	var ids []uint64
+	err := mysql.GetDB(ctx, d.db.DB).Model(&model.RoomMember{}).
+		Where("room_id = ? AND role = ?", roomId, uint8(types.RoleAdmin)).
+		Pluck("user_id", &ids).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, room.ErrDBOperate
+	}
+	return ids, nil
+}
+
+// Exists 是否房间成员
+func (d *RoomMemberRepo) Exists(ctx context.Context, roomId, userId uint64) (bool, error) {
+	var count int64
+	err := mysql.GetDB(ctx, d.db.DB).Model(&model.RoomMember{}).
+		Where("room_id = ? AND user_id = ?", roomId, userId).
+		Count(&count).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return false, room.ErrDBOperate
+	}
+	return count > 0, nil
+}
+
+// Count 获取房间成员数量
+func (d *RoomMemberRepo) Count(ctx context.Context, roomId uint64) (int64, error) {
+	var count int64
+	err := mysql.GetDB(ctx, d.db.DB).Model(&model.RoomMember{}).
+		Where("room_id = ?", roomId).
+		Count(&count).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return 0, room.ErrDBOperate
+	}
+	return count, nil
+}
+
+// CountByRooms 批量获取房间成员数量
+func (d *RoomMemberRepo) CountByRooms(ctx context.Context, roomIds []uint64) (map[uint64]int64, error) {
+	result := make(map[uint64]int64, len(roomIds))
+	if len(roomIds) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		RoomId uint64
+		Total  int64
+	}
+	err := mysql.GetDB(ctx, d.db.DB).Model(&model.RoomMember{}).
+		Select("room_id, COUNT(*) AS total").
+		Where("room_id IN ?", roomIds).
+		Group("room_id").
+		Scan(&rows).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, room.ErrDBOperate
+	}
+	for _, row := range rows {
+		result[row.RoomId] = row.Total
+	}
+	return result, nil
+}
+
+// Remove 删除成员
+func (d *RoomMemberRepo) Remove(ctx context.Context, roomId, userId uint64) error {
+	err := mysql.GetDB(ctx, d.db.DB).
+		Where("room_id = ? AND user_id = ?", roomId, userId).
+		Delete(&model.RoomMember{}).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return room.ErrDBOperate
+	}
+	return nil
+}
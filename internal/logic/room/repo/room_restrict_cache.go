@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	pkgRedis "go-im/pkg/redis"
+	"go-im/pkg/util"
+	"strconv"
+	"time"
+)
+
+/**
+ * @Description: 房间成员限制（禁止加入、禁言），记录限制的截止时间，0 表示永久
+ */
+
+func NewRoomBanCache() *RoomRestrictCache {
+	return &RoomRestrictCache{rdClient: pkgRedis.C(pkgRedis.NAME_DEFAULT), prefix: cacheKeyRoomBan}
+}
+
+func NewRoomMuteCache() *RoomRestrictCache {
+	return &RoomRestrictCache{rdClient: pkgRedis.C(pkgRedis.NAME_DEFAULT), prefix: cacheKeyRoomMute}
+}
+
+type RoomRestrictCache struct {
+	rdClient *redis.Client
+	prefix   string
+}
+
+// Set 设置限制，expireAt 为截止时间（秒级时间戳），0 表示永久
+func (r *RoomRestrictCache) Set(roomId, userId uint64, expireAt int64) error {
+	return r.rdClient.HSet(context.Background(), r.cKey(roomId), util.Uint64ToString(userId), expireAt).Err()
+}
+
+// Remove 解除限制
+func (r *RoomRestrictCache) Remove(roomId, userId uint64) error {
+	return r.rdClient.HDel(context.Background(), r.cKey(roomId), util.Uint64ToString(userId)).Err()
+}
+
+// Get 获取限制的截止时间，第二个返回值表示是否处于限制中。已过期的限制会被删除
+func (r *RoomRestrictCache) Get(roomId, userId uint64) (int64, bool) {
+	val, err := r.rdClient.HGet(context.Background(), r.cKey(roomId), util.Uint64ToString(userId)).Result()
+	if err != nil {
+		return 0, false
+	}
+	expireAt, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	if expireAt > 0 && expireAt <= time.Now().Unix() {
+		_ = r.Remove(roomId, userId)
+		return 0, false
+	}
+	return expireAt, true
+}
+
+// DeleteRoom 删除整个房间的限制数据
+func (r *RoomRestrictCache) DeleteRoom(roomId uint64) int64 {
+	return r.rdClient.Del(context.Background(), r.cKey(roomId)).Val()
+}
+
+func (r *RoomRestrictCache) cKey(roomId uint64) string {
+	return r.prefix + util.Uint64ToString(roomId)
+}
@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"go-im/internal/logic/room/types"
+	pkgRedis "go-im/pkg/redis"
+	"go-im/pkg/util"
+	"strconv"
+	"time"
+)
+
+/**
+ * @Description: 房间管理员缓存（管理员持久化在 room_member 表中，创建者记录在房间信息中）
+ */
+
+const (
+	roomRoleExpire = 10 * time.Minute // 缓存有效时间
+	roomRoleLoaded = "0"              // 已从数据库加载的标记字段（用户id不会为 0）
+)
+
+func NewRoomRoleCache() *RoomRoleCache {
+	return &RoomRoleCache{rdClient: pkgRedis.C(pkgRedis.NAME_DEFAULT)}
+}
+
+type RoomRoleCache struct {
+	rdClient *redis.Client
+}
+
+// Get 获取房间成员角色（不包含普通成员），缓存不存在时返回 false，需要从数据库加载
+func (r *RoomRoleCache) Get(roomId uint64) (map[uint64]types.Role, bool) {
+	all, err := r.rdClient.HGetAll(context.Background(), r.cKey(roomId)).Result()
+	if err != nil {
+		return nil, false
+	}
+	if _, ok := all[roomRoleLoaded]; !ok {
+		return nil, false
+	}
+
+	roles := make(map[uint64]types.Role, len(all))
+	for uid, val := range all {
+		if uid == roomRoleLoaded {
+			continue
+		}
+		id, err := util.StringToUint64(uid)
+		if err != nil {
+			continue
+		}
+		role, err := strconv.ParseUint(val, 10, 8)
+		if err != nil {
+			continue
+		}
+		roles[id] = types.Role(role)
+	}
+	return roles, true
+}
+
+// Set 缓存房间成员角色（覆盖原有数据）
+func (r *RoomRoleCache) Set(roomId uint64, roles map[uint64]types.Role) error {
+	values := make([]any, 0, len(roles)*2+2)
+	values = append(values, roomRoleLoaded, 0)
+	for uid, role := range roles {
+		values = append(values, util.Uint64ToString(uid), uint8(role))
+	}
+
+	key := r.cKey(roomId)
+	_, err := r.rdClient.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.Del(context.Background(), key)
+		pipe.HSet(context.Background(), key, values...)
+		pipe.Expire(context.Background(), key, roomRoleExpire)
+		return nil
+	})
+	return err
+}
+
+// DeleteRoom 删除整个房间的角色缓存，下次读取时从数据库重新加载
+func (r *RoomRoleCache) DeleteRoom(roomId uint64) error {
+	return r.rdClient.Del(context.Background(), r.cKey(roomId)).Err()
+}
+
+func (r *RoomRoleCache) cKey(roomId uint64) string {
+	return cacheKeyRoomRole + util.Uint64ToString(roomId)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/redis/go-redis/v9"
+	"go-im/internal/logic/room/types"
 	"go-im/pkg/logger"
 	pkgRedis "go-im/pkg/redis"
 	"go-im/pkg/util"
@@ -27,9 +28,15 @@ func (r *RoomUserCache) Exists(roomId, userId uint64) bool {
 	return exist
 }
 
-// 创建
+// 创建（同时写入按用户id排序的索引，用于分页）
 func (r *RoomUserCache) Create(roomId, userId uint64, username string) int64 {
-	return r.rdClient.HSet(context.Background(), r.cKey(roomId), util.Uint64ToString(userId), username).Val()
+	var hset *redis.IntCmd
+	_, _ = r.rdClient.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		hset = pipe.HSet(context.Background(), r.cKey(roomId), util.Uint64ToString(userId), username)
+		pipe.ZAdd(context.Background(), r.idxKey(roomId), redis.Z{Score: float64(userId), Member: util.Uint64ToString(userId)})
+		return nil
+	})
+	return hset.Val()
 }
 
 // 获取全部数据
@@ -37,16 +44,75 @@ func (r *RoomUserCache) GetAll(roomId uint64) map[string]string {
 	return r.rdClient.HGetAll(context.Background(), r.cKey(roomId)).Val()
 }
 
+// Page 按用户id升序分页获取数据，cursor 为上一页最后一个用户id（第一页传 0），
+// 返回下一页的游标，游标为 0 表示没有更多数据
+func (r *RoomUserCache) Page(roomId, cursor uint64, size int64) (types.UserList, uint64, error) {
+	ctx := context.Background()
+	ids, err := r.rdClient.ZRangeByScore(ctx, r.idxKey(roomId), &redis.ZRangeBy{
+		Min:   "(" + util.Uint64ToString(cursor),
+		Max:   "+inf",
+		Count: size + 1,
+	}).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	more := int64(len(ids)) > size
+	if more {
+		ids = ids[:size]
+	}
+	if len(ids) == 0 {
+		return types.UserList{}, 0, nil
+	}
+
+	names, err := r.rdClient.HMGet(ctx, r.cKey(roomId), ids...).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	list := make(types.UserList, 0, len(ids))
+	for i, id := range ids {
+		name, ok := names[i].(string)
+		uid, _ := util.StringToUint64(id)
+		if !ok || uid == 0 {
+			continue
+		}
+		list = append(list, types.UserItem{Id: uid, Name: name})
+	}
+
+	var next uint64
+	if more {
+		next, _ = util.StringToUint64(ids[len(ids)-1])
+	}
+	return list, next, nil
+}
+
+// Count 获取数量
+func (r *RoomUserCache) Count(roomId uint64) int64 {
+	return r.rdClient.HLen(context.Background(), r.cKey(roomId)).Val()
+}
+
 // 删除
 func (r *RoomUserCache) Remove(roomId, userId uint64) int64 {
-	return r.rdClient.HDel(context.Background(), r.cKey(roomId), util.Uint64ToString(userId)).Val()
+	var hdel *redis.IntCmd
+	_, _ = r.rdClient.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		hdel = pipe.HDel(context.Background(), r.cKey(roomId), util.Uint64ToString(userId))
+		pipe.ZRem(context.Background(), r.idxKey(roomId), util.Uint64ToString(userId))
+		return nil
+	})
+	return hdel.Val()
 }
 
 // 删除整个房间数据
 func (r *RoomUserCache) DeleteRoom(roomId uint64) int64 {
-	return r.rdClient.Del(context.Background(), r.cKey(roomId)).Val()
+	return r.rdClient.Del(context.Background(), r.cKey(roomId), r.idxKey(roomId)).Val()
 }
 
 func (r *RoomUserCache) cKey(roomId uint64) string {
 	return fmt.Sprintf("room:%d", roomId)
 }
+
+// 在线用户id索引（有序集合，score 为用户id）
+func (r *RoomUserCache) idxKey(roomId uint64) string {
+	return fmt.Sprintf("room:%d:ids", roomId)
+}
@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"go-im/pkg/logger"
+	pkgRedis "go-im/pkg/redis"
+	"go-im/pkg/util"
+	"go.uber.org/zap"
+	"time"
+)
+
+/**
+ * @Description: 在线用户所连接的 IM service 服务id（不区分房间）
+ */
+
+func NewUserOnlineCache() *UserOnlineCache {
+	return &UserOnlineCache{
+		rdClient: pkgRedis.C(pkgRedis.NAME_DEFAULT),
+	}
+}
+
+type UserOnlineCache struct {
+	rdClient *redis.Client
+}
+
+// Online 设置用户在线
+func (r *UserOnlineCache) Online(userId uint64, serverId string) {
+	if err := r.rdClient.HSet(context.Background(), cacheKeyUserOnline, util.Uint64ToString(userId), serverId).Err(); err != nil {
+		logger.Error("set user online error", zap.Uint64("user_id", userId), zap.Error(err))
+	}
+}
+
+// GetServerId 获取用户连接的 serviceId，返回空字符串表示用户不在线（所在服务心跳已过期时同时清除在线状态）
+func (r *UserOnlineCache) GetServerId(userId uint64) string {
+	serverId := r.rdClient.HGet(context.Background(), cacheKeyUserOnline, util.Uint64ToString(userId)).Val()
+	if serverId != "" && !r.ServerAlive(serverId) {
+		r.Offline(userId, serverId)
+		return ""
+	}
+	return serverId
+}
+
+// IsOnline 用户是否在线
+func (r *UserOnlineCache) IsOnline(userId uint64) bool {
+	return r.GetServerId(userId) != ""
+}
+
+// OnlineMap 批量获取用户是否在线
+func (r *UserOnlineCache) OnlineMap(userIds []uint64) map[uint64]bool {
+	result := make(map[uint64]bool, len(userIds))
+	if len(userIds) == 0 {
+		return result
+	}
+
+	fields := make([]string, 0, len(userIds))
+	for _, uid := range userIds {
+		fields = append(fields, util.Uint64ToString(uid))
+	}
+	values, err := r.rdClient.HMGet(context.Background(), cacheKeyUserOnline, fields...).Result()
+	if err != nil {
+		logger.Error("get user online error", zap.Error(err))
+		return result
+	}
+	alive := make(map[string]bool)
+	for i, val := range values {
+		serverId, ok := val.(string)
+		if !ok || serverId == "" {
+			continue
+		}
+		if _, checked := alive[serverId]; !checked {
+			alive[serverId] = r.ServerAlive(serverId)
+		}
+		if alive[serverId] {
+			result[userIds[i]] = true
+		}
+	}
+	return result
+}
+
+// Offline 设置用户下线（仅当用户仍连接在 serverId 上时删除，防止删除用户在其他服务的登录状态）
+func (r *UserOnlineCache) Offline(userId uint64, serverId string) bool {
+	script := `
+	if( redis.call("HGET", KEYS[1], ARGV[1]) == ARGV[2] ) then
+		return redis.call("HDEL", KEYS[1], ARGV[1])
+	end
+	return 0
+`
+	result, err := redis.NewScript(script).Run(context.Background(), r.rdClient, []string{cacheKeyUserOnline}, userId, serverId).Int64()
+	if err != nil {
+		logger.Error("user offline lua script error", zap.Error(err))
+		return false
+	}
+	return result == 1
+}
+
+// KeepAlive 刷新服务心跳，心跳过期的服务（异常退出）记录的在线用户视为离线
+func (r *UserOnlineCache) KeepAlive(serverId string, ttl time.Duration) error {
+	return r.rdClient.Set(context.Background(), cacheKeyServerAlive+serverId, 1, ttl).Err()
+}
+
+// ServerAlive 服务是否存活（查询失败时视为存活，避免误删在线状态）
+func (r *UserOnlineCache) ServerAlive(serverId string) bool {
+	n, err := r.rdClient.Exists(context.Background(), cacheKeyServerAlive+serverId).Result()
+	if err != nil {
+		logger.Error("get server alive error", zap.String("server_id", serverId), zap.Error(err))
+		return true
+	}
+	return n > 0
+}
+
+// ClearDead 清除心跳已过期的服务记录的在线用户
+func (r *UserOnlineCache) ClearDead() {
+	var (
+		ctx    = context.Background()
+		cursor uint64
+		alive  = make(map[string]bool)
+	)
+	for {
+		keys, next, err := r.rdClient.HScan(ctx, cacheKeyUserOnline, cursor, "", 500).Result()
+		if err != nil {
+			logger.Error("scan user online error", zap.Error(err))
+			return
+		}
+
+		for i := 0; i+1 < len(keys); i += 2 {
+			serverId := keys[i+1]
+			if _, checked := alive[serverId]; !checked {
+				alive[serverId] = r.ServerAlive(serverId)
+			}
+			if alive[serverId] {
+				continue
+			}
+			if userId, _ := util.StringToUint64(keys[i]); userId > 0 {
+				r.Offline(userId, serverId)
+			}
+		}
+
+		if cursor = next; cursor == 0 {
+			return
+		}
+	}
+}
@@ -3,9 +3,11 @@ package service
 import (
 	"github.com/gorilla/websocket"
 	"go-im/internal/connect"
+	msgService "go-im/internal/logic/message/service"
 	"go-im/internal/logic/room/repo"
 	"go-im/internal/logic/room/types"
 	"go-im/internal/logic/user/service"
+	"go-im/pkg/logger"
 	"sync"
 )
 
@@ -14,10 +16,22 @@ var _ IService = (*Service)(nil)
 func NewService() *Service {
 	srv := &Service{
 		userService:      service.NewUserService(),
+		msgService:       msgService.NewMessageService(),
 		roomUserCache:    repo.NewRooUserCache(),
 		userServiceCache: repo.NewUserServiceCache(),
 		roomCache:        repo.NewRoomCache(),
+		roomRepo:         repo.NewRoomRepo(),
+		roomMemberRepo:   repo.NewRoomMemberRepo(),
+		roomInviteRepo:   repo.NewRoomInviteRepo(),
+		joinRequestRepo:  repo.NewRoomJoinRequestRepo(),
+		roomRoleCache:    repo.NewRoomRoleCache(),
+		roomBanCache:     repo.NewRoomBanCache(),
+		roomMuteCache:    repo.NewRoomMuteCache(),
+		userOnlineCache:  repo.NewUserOnlineCache(),
+		offlineMsgCache:  repo.NewOfflineMsgCache(),
+		requestIdCache:   repo.NewRequestIdCache(),
 		roomsManager:     make(map[uint64]*Room),
+		typingStates:     make(map[uint64]*typingState),
 		strategy:         MsgStrategy{},
 	}
 
@@ -29,11 +43,33 @@ func NewService() *Service {
 	srv.strategy.Register(types.MethodRoomList, srv.roomList)
 	srv.strategy.Register(types.MethodCreateRoomNotice, srv.createRoomNotice)
 	srv.strategy.Register(types.MethodOffline, srv.leaveRoom)
+	srv.strategy.Register(types.MethodHistory, srv.history)
+	srv.strategy.Register(types.MethodSyncMsg, srv.syncMsg)
+	srv.strategy.Register(types.MethodDeliveryAck, srv.deliveryAck)
+	srv.strategy.Register(types.MethodMarkRead, srv.markRead)
+	srv.strategy.Register(types.MethodTyping, srv.typing)
+	srv.strategy.Register(types.MethodRecall, srv.recall)
+	srv.strategy.Register(types.MethodEdit, srv.edit)
+	srv.strategy.Register(types.MethodCloseRoom, srv.closeRoom)
+	srv.strategy.Register(types.MethodSetAdmin, srv.setAdmin)
+	srv.strategy.Register(types.MethodTransferOwner, srv.transferOwner)
+	srv.strategy.Register(types.MethodKick, srv.kick)
+	srv.strategy.Register(types.MethodBan, srv.ban)
+	srv.strategy.Register(types.MethodMute, srv.mute)
+	srv.strategy.Register(types.MethodCreateInvite, srv.createInvite)
+	srv.strategy.Register(types.MethodApplyJoin, srv.applyJoin)
+	srv.strategy.Register(types.MethodJoinRequestList, srv.joinRequestList)
+	srv.strategy.Register(types.MethodHandleJoinRequest, srv.handleJoinRequest)
+	srv.strategy.Register(types.MethodUpdateRoom, srv.updateRoom)
+	srv.strategy.Register(types.MethodOnlineCount, srv.onlineCount)
+	srv.strategy.Register(types.MethodInviteUser, srv.inviteUser)
 
 	return srv
 }
 
 type IService interface {
+	// 连接建立
+	Open(n *connect.Node)
 	// 分发消息
 	Dispatch(n *connect.Node, message []byte)
 	// 网关消息
@@ -46,42 +82,59 @@ type IService interface {
 
 type Service struct {
 	userService      service.IService
+	msgService       msgService.IService
 	userServiceCache *repo.UserServiceCache
 	roomUserCache    *repo.RoomUserCache
 	roomCache        *repo.RoomCache
+	roomRepo         *repo.RoomRepo
+	roomMemberRepo   *repo.RoomMemberRepo
+	roomInviteRepo   *repo.RoomInviteRepo
+	joinRequestRepo  *repo.RoomJoinRequestRepo
+	roomRoleCache    *repo.RoomRoleCache
+	roomBanCache     *repo.RoomRestrictCache
+	roomMuteCache    *repo.RoomRestrictCache
+	userOnlineCache  *repo.UserOnlineCache
+	offlineMsgCache  *repo.OfflineMsgCache
+	requestIdCache   *repo.RequestIdCache
 	roomsManager     map[uint64]*Room
-	newRoomLock      sync.Mutex
+	roomsLock        sync.RWMutex
 	strategy         MsgStrategy
+	typingStates     map[uint64]*typingState // 用户正在输入状态
+	typingLock       sync.Mutex
+	serverId         string // 当前服务id
 }
 
 type Room struct {
-	RoomId    uint64 // 房间ID
-	name      string // 房间名称
-	clients   map[uint64]*connect.Node
-	joinLock  sync.RWMutex
-	leaveLock sync.RWMutex
-	pushLock  sync.RWMutex
+	RoomId     uint64                   // 房间ID
+	ownerId    uint64                   // 房间创建者
+	name       string                   // 房间名称
+	private    bool                     // 是否私有房间
+	approval   bool                     // 加入是否需要审核
+	maxMembers int64                    // 成员上限，0 表示使用默认上限
+	clients    map[uint64]*connect.Node // 当前服务中订阅房间的连接
+	lock       sync.RWMutex             // 连接互斥锁
+	infoLock   sync.RWMutex             // 房间信息（创建者、名称、成员上限）读写锁，房间信息变更时会被其他协程修改
 }
 
-// 判断用户是否在加入房间
+// 判断用户是否在加入房间（连接未订阅但缓存中已加入时，补充订阅）
 func (s *Service) isInRoom(n *connect.Node, roomId uint64) bool {
 	if roomId == 0 {
 		return false
 	}
 
-	if n.RoomId == roomId {
+	if n.InRoom(roomId) {
 		return true
 	}
 
-	if n.RoomId == 0 {
-		if s.roomUserCache.Exists(n.UserId, roomId) {
-			n.RoomId = roomId
-			return true
-		}
+	if !s.roomUserCache.Exists(roomId, n.UserId) {
 		return false
 	}
-
-	return false
+	r := s.syncGetRoom(roomId)
+	if r == nil {
+		return false
+	}
+	s.subscribeRoom(r, n)
+	return true
 }
 
 // 发送成功消息
@@ -92,7 +145,6 @@ func (s *Service) sendSuccessMsg(n *connect.Node, RequestId string, method types
 		Msg:       types.CodeSuccess.Name(),
 		Method:    method,
 		Data:      data,
-		RoomId:    n.RoomId,
 	}
 
 	n.DataQueue <- result.Marshal()
@@ -108,7 +160,6 @@ func (s *Service) sendErrorMsg(n *connect.Node, RequestId string, method types.M
 		Code:      code,
 		Msg:       msg,
 		Method:    method,
-		RoomId:    n.RoomId,
 	}
 
 	n.DataQueue <- result.Marshal()
@@ -126,6 +177,9 @@ func (s *Service) getOutput(n *connect.Node, data *types.Input) *types.Output {
 		FromUsername: s.userService.UserIdName(n.UserId),
 		ToUid:        data.ToUid,
 		FromServer:   n.ServerId,
+		MsgId:        data.MsgId,
+		Seq:          data.Seq,
+		SendTime:     data.SendTime,
 	}
 }
 
@@ -147,18 +201,71 @@ func (s *Service) allServiceRoomMsg(n *connect.Node, data *types.Input) bool {
 
 // ack 确认
 func (s *Service) ack(n *connect.Node, data *types.Input) {
-	s.sendSuccessMsg(n, data.RequestId, types.MethodServiceAck, nil)
+	s.sendSuccessMsg(n, data.RequestId, types.MethodServiceAck, s.ackResult(data))
+}
+
+// 消息确认结果
+func (s *Service) ackResult(data *types.Input) *types.AckResult {
+	return &types.AckResult{
+		MsgId:    data.MsgId,
+		Seq:      data.Seq,
+		SendTime: data.SendTime,
+	}
 }
 
 // 发送房间消息（当前服务器）
 func (s *Service) sendServerRoom(n *connect.Node, data *types.Input) {
 	out := s.getOutput(n, data)
 
-	if r := s.getRoom(n.RoomId); r != nil {
+	if r := s.getRoom(data.RoomId); r != nil {
 		s.pushRoom(r, out.QueueMsgData())
 	}
 }
 
+// 发送私聊消息
+func (s *Service) sendUserMsg(n *connect.Node, data *types.Input) {
+	s.deliverUserMsg(s.getOutput(n, data).QueueMsgData())
+}
+
+// 投递私聊消息（接收者不在线时保存为离线消息）
+func (s *Service) deliverUserMsg(data *types.QueueMsgData) {
+	if !s.relayUserMsg(data) {
+		s.saveOfflineMsg(data.ToUid, data)
+	}
+}
+
+// 转发消息给在线用户（接收者连接在当前服务器时直接投递，在其他服务器时通过网关转发）。返回 false 表示接收者不在线
+func (s *Service) relayUserMsg(data *types.QueueMsgData) bool {
+	if node := connect.GetNode(data.ToUid); node != nil {
+		s.pushUser(node, data)
+		return true
+	}
+
+	serverId := s.userOnlineCache.GetServerId(data.ToUid)
+	if serverId == "" {
+		return false
+	}
+	// 在线状态指向当前服务但连接已不存在，网关不会将消息转发回来源服务，视为不在线
+	if serverId == s.serverId {
+		s.userOnlineCache.Offline(data.ToUid, serverId)
+		return false
+	}
+
+	connect.SendGatewayMsg(data.Marshal())
+	return true
+}
+
+// 推送消息给指定连接
+func (s *Service) pushUser(n *connect.Node, data *types.QueueMsgData) {
+	defer func() {
+		if err := recover(); err != nil {
+			logger.Errorf("push user msg error: %v", err)
+		}
+	}()
+
+	n.PushMsg(data.MsgId, data.MarshalOutput(data.RoomId))
+}
+
 // 广播消息（全部在线用户，区分房间）
 func (s *Service) broadcastMsg(n *connect.Node, data *types.Input) {
 	// 广播消息
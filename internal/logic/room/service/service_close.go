@@ -1,15 +1,44 @@
 package service
 
 import (
+	"encoding/json"
 	"go-im/internal/connect"
+	"go-im/internal/logic/room/types"
+	"go-im/pkg/logger"
+	"go.uber.org/zap"
 )
 
 // 处理关闭
 func (s *Service) Close(n *connect.Node) {
-	if n.RoomId > 0 {
-		s.roomUserCache.Remove(n.RoomId, n.UserId)
-		s.userServiceCache.Remove(n.RoomId, n.UserId)
+	// 当前服务已存在该用户的新连接时，不修改在线状态
+	if node := connect.GetNode(n.UserId); node == nil || node == n {
+		s.userOnlineCache.Offline(n.UserId, n.ServerId)
+	}
+
+	// 重新投递未确认的私聊消息（群聊消息由客户端通过消息序号同步）
+	s.redeliverPending(n)
+
+	s.clearTyping(n)
+
+	// 离开订阅的全部房间
+	for _, roomId := range n.RoomIds() {
+		s.leave(n, roomId)
+	}
+}
+
+// 重新投递连接中未确认的私聊消息
+func (s *Service) redeliverPending(n *connect.Node) {
+	for _, msg := range n.AckWindow.Pending() {
+		var data = new(types.QueueMsgData)
+		if err := json.Unmarshal(msg, data); err != nil {
+			logger.Error("pending msg unmarshal error", zap.Uint64("user_id", n.UserId), zap.Error(err))
+			continue
+		}
 
-		s.leaveRoom(n, nil)
+		if data.Method == types.MethodNormal && data.ToUid == n.UserId {
+			// 网关不会将消息转发回来源服务，因此由当前服务作为来源重新投递
+			data.FromServer = n.ServerId
+			s.deliverUserMsg(data)
+		}
 	}
 }
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"go-im/internal/connect"
+	"go-im/internal/logic/room/types"
+	"go-im/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// 关闭（解散）房间，仅房间创建者可操作
+func (s *Service) closeRoom(n *connect.Node, data *types.Input) {
+	r, ok := s.checkPermission(n, data, types.MethodCloseRoom, types.PermCloseRoom)
+	if !ok {
+		return
+	}
+
+	ok, err := s.roomRepo.Close(context.Background(), r.RoomId)
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodCloseRoom, types.CodeError, "关闭房间失败，请稍后再试。")
+		return
+	}
+	if !ok {
+		s.sendErrorMsg(n, data.RequestId, types.MethodCloseRoom, types.CodeValidateError, "房间不存在")
+		return
+	}
+
+	// 清理房间缓存
+	s.roomCache.Remove(r.RoomId)
+	s.roomUserCache.DeleteRoom(r.RoomId)
+	s.userServiceCache.DeleteRoom(r.RoomId)
+	s.roomRoleCache.DeleteRoom(r.RoomId)
+	s.roomBanCache.DeleteRoom(r.RoomId)
+	s.roomMuteCache.DeleteRoom(r.RoomId)
+
+	roomInfo := types.RoomInfo{
+		Id:      r.RoomId,
+		Name:    r.getName(),
+		OwnerId: r.getOwnerId(),
+		Private: r.private,
+	}
+	s.sendSuccessMsg(n, data.RequestId, types.MethodCloseRoom, roomInfo)
+
+	// 通知其他服务及全部用户，房间已关闭
+	out := s.getOutput(n, &types.Input{
+		Data:   roomInfo,
+		RoomId: r.RoomId,
+		Method: types.MethodRoomClosedNotice.Uint8(),
+	}).QueueMsgData()
+	n.BroadcastQueue <- out.Marshal()
+	s.dissolveRoom(out)
+}
+
+// 解散当前服务的房间：移除房间对象及房间内的连接。公开房间通知全部用户（更新房间列表），私有房间只通知房间成员
+func (s *Service) dissolveRoom(data *types.QueueMsgData) {
+	var info types.RoomInfo
+	_ = data.BindData(&info)
+	if info.Private {
+		s.SendRoomMsg(data.RoomId, data)
+	}
+
+	s.roomsLock.Lock()
+	r, ok := s.roomsManager[data.RoomId]
+	delete(s.roomsManager, data.RoomId)
+	s.roomsLock.Unlock()
+
+	if ok {
+		r.lock.Lock()
+		for uid, node := range r.clients {
+			node.LeaveRoom(r.RoomId)
+			delete(r.clients, uid)
+		}
+		r.lock.Unlock()
+		logger.Debug("dissolve room", zap.Uint64("room_id", r.RoomId))
+	}
+
+	if !info.Private {
+		// 通知用户更新房间列表
+		connect.PushAll(data)
+	}
+}
@@ -1,45 +1,100 @@
 package service
 
 import (
+	"context"
+	"fmt"
+	"go-im/config"
 	"go-im/internal/connect"
+	"go-im/internal/logic/room/model"
 	"go-im/internal/logic/room/types"
+	"time"
+	"unicode/utf8"
 )
 
-// 创建房间
+// 房间名称最大长度
+const roomNameMaxLen = 50
+
+// 创建房间，Data 为房间名称或 CreateRoomReq
 func (s *Service) create(n *connect.Node, data *types.Input) {
-	//n := connect.GetNode(userId)
-	//if n == nil {
-	//	return
-	//}
-	roomId := n.UserId // 房间id，使用用户id创建，为了简化判断逻辑。一个用户只能创建一个群聊
-
-	roomName, ok := data.Data.(string)
-	if !ok || roomName == "" {
+	var req types.CreateRoomReq
+	if name, ok := data.Data.(string); ok {
+		req.Name = name
+	} else if err := data.BindData(&req); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodCreateRoom, types.CodeValidateError, "参数格式有误")
+		return
+	}
+	roomName := req.Name
+	if roomName == "" || utf8.RuneCountInString(roomName) > roomNameMaxLen {
 		s.sendErrorMsg(n, data.RequestId, types.MethodCreateRoom, types.CodeValidateError, "房间名称格式有误")
+		return
+	}
+
+	// 房间id由数据库生成
+	roomModel := &model.Room{
+		OwnerId:      n.UserId,
+		Name:         roomName,
+		JoinApproval: req.JoinApproval,
+	}
+	if req.Private {
+		roomModel.Visibility = model.VisibilityPrivate
 	}
 
-	// 房间已存在，返回错误信息
-	isCreate, err := s.roomCache.Create(n.UserId, roomName)
+	// 检查创建房间数量并创建房间，创建者也是房间成员
+	ctx := context.Background()
+	quota := config.C.Room.OwnerQuota
+	overQuota := false
+	err := s.roomRepo.Transaction(ctx, func(txCtx context.Context) error {
+		if quota > 0 {
+			// 锁定创建者，并发创建时不会超出数量限制
+			if err := s.roomRepo.LockOwner(txCtx, n.UserId); err != nil {
+				return err
+			}
+			count, err := s.roomRepo.CountByOwner(txCtx, n.UserId)
+			if err != nil {
+				return err
+			}
+			if count >= quota {
+				overQuota = true
+				return nil
+			}
+		}
+		if err := s.roomRepo.Add(txCtx, roomModel); err != nil {
+			return err
+		}
+		return s.roomMemberRepo.Add(txCtx, roomModel.Id, n.UserId)
+	})
 	if err != nil {
 		s.sendErrorMsg(n, data.RequestId, types.MethodCreateRoom, types.CodeError, "创建房间失败，请稍后再试。")
 		return
 	}
-	if !isCreate {
-		s.sendErrorMsg(n, data.RequestId, types.MethodCreateRoom, types.CodeValidateError, "您已创建房间，不能重复创建")
+	if overQuota {
+		s.sendErrorMsg(n, data.RequestId, types.MethodCreateRoom, types.CodeValidateError, fmt.Sprintf("最多只能创建 %d 个房间", quota))
 		return
 	}
+	s.setRoomCache(roomModel)
 
-	s.newRoom(roomId, roomName)
+	s.newRoom(roomModel.Id, roomCacheItem(roomModel))
 
 	roomInfo := types.RoomInfo{
-		Id:   roomId,
-		Name: roomName,
+		Id:           roomModel.Id,
+		Name:         roomName,
+		OwnerId:      n.UserId,
+		Private:      req.Private,
+		JoinApproval: req.JoinApproval,
+		CreatedAt:    time.Now().Unix(),
+	}
+
+	s.sendSuccessMsg(n, data.RequestId, types.MethodCreateRoom, roomInfo)
+
+	// 私有房间不公开，无需通知
+	if req.Private {
+		return
 	}
 
 	// 通知群用户，新创建了房间
 	s.broadcastMsg(n, &types.Input{
 		Data:   roomInfo,
-		RoomId: roomId,
+		RoomId: roomModel.Id,
 		Method: types.MethodCreateRoomNotice.Uint8(),
 	})
 }
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"go-im/internal/connect"
+	"go-im/internal/logic/room/types"
+)
+
+// 客户端确认已收到消息
+func (s *Service) deliveryAck(n *connect.Node, data *types.Input) {
+	var req types.DeliveryAckReq
+	if err := data.BindData(&req); err != nil || len(req.MsgIds) == 0 {
+		s.sendErrorMsg(n, data.RequestId, types.MethodDeliveryAck, types.CodeValidateError, "参数格式有误")
+		return
+	}
+
+	n.AckWindow.Ack(req.MsgIds...)
+}
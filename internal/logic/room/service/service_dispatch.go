@@ -15,9 +15,12 @@ func (s *Service) Dispatch(n *connect.Node, message []byte) {
 		return
 	}
 
-	// 设置房间id默认值
-	if data.RoomId == 0 {
-		data.RoomId = n.RoomId
+	// 设置群聊消息的房间id默认值：兼容旧版客户端，连接只订阅了一个房间时，未指定房间的群聊消息发送到该房间。
+	// 其他方法必须明确指定房间，避免操作到错误的房间
+	if data.Method == types.MethodGroup.Uint8() && data.RoomId == 0 {
+		if roomIds := n.RoomIds(); len(roomIds) == 1 {
+			data.RoomId = roomIds[0]
+		}
 	}
 
 	method := s.strategy.Get(types.MsgMethod(data.Method))
@@ -20,12 +20,28 @@ func (s *Service) GatewayMsg(wsConn *websocket.Conn, message []byte) {
 	}
 
 	switch data.Method {
-	case types2.MethodNormal: // 普通消息。发送指定用户
-		if node := connect.GetNode(data.ToUid); node != nil {
-			node.DataQueue <- data.MarshalOutput(node.RoomId)
+	case types2.MethodNormal, types2.MethodReadReceipt, types2.MethodJoinRequestNotice, types2.MethodJoinRequestResult,
+		types2.MethodFriendNotice, types2.MethodRoomInviteNotice: // 普通消息、已读回执、加入申请、好友及房间邀请通知。发送指定用户
+		s.gatewayUserMsg(data)
+	case types2.MethodTyping, types2.MethodRecall, types2.MethodEdit: // 正在输入、撤回、编辑消息。私聊发送指定用户，群聊发送房间用户
+		if data.ToUid == 0 {
+			s.SendRoomMsg(data.RoomId, data)
+		} else {
+			s.gatewayUserMsg(data)
 		}
 	case types2.MethodCreateRoomNotice: // 创建房间
 		connect.PushAll(data)
+	case types2.MethodRoomUpdatedNotice: // 修改房间信息
+		s.roomUpdated(data)
+	case types2.MethodUserUpdatedNotice: // 用户资料变更
+		s.userUpdated(data)
+	case types2.MethodRoomClosedNotice: // 关闭房间
+		s.dissolveRoom(data)
+	case types2.MethodRoleChangeNotice: // 成员角色变更
+		s.applyRoleChange(data)
+		s.SendRoomMsg(data.RoomId, data)
+	case types2.MethodModerateNotice: // 成员管理
+		s.handleModerate(data)
 	case types2.MethodForceOfflineBroadcast: // 强制线下通知
 		if mapNode := connect.GetNode(data.FromUid); mapNode != nil {
 			// 由于发送方服务器在连接层已经处理，因此不需要处理，防止删除发送方服务器未登录的账号
@@ -41,3 +57,19 @@ func (s *Service) GatewayMsg(wsConn *websocket.Conn, message []byte) {
 		s.SendRoomMsg(data.RoomId, data)
 	}
 }
+
+// 推送网关转发的私聊消息。接收者的在线状态指向当前服务但连接已不存在时（在线状态过期），清除在线状态并保存为离线消息
+func (s *Service) gatewayUserMsg(data *types2.QueueMsgData) {
+	if node := connect.GetNode(data.ToUid); node != nil {
+		s.pushUser(node, data)
+		return
+	}
+
+	// 正在输入状态不需要离线保存
+	if data.Method == types2.MethodTyping {
+		return
+	}
+	if s.userOnlineCache.Offline(data.ToUid, s.serverId) {
+		s.saveOfflineMsg(data.ToUid, data)
+	}
+}
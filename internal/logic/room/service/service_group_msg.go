@@ -11,6 +11,20 @@ func (s *Service) groupMsg(n *connect.Node, data *types.Input) {
 	if n == nil {
 		return
 	}*/
+	if !s.isInRoom(n, data.RoomId) {
+		s.sendErrorMsg(n, data.RequestId, types.MethodGroup, types.CodeValidateError, "未加入群聊")
+		return
+	}
+
+	if expireAt, muted := s.roomMuteCache.Get(data.RoomId, n.UserId); muted {
+		s.sendErrorMsg(n, data.RequestId, types.MethodGroup, types.CodeValidateError, muteMsg(expireAt))
+		return
+	}
+
+	if !s.parseContent(n, data, types.MethodGroup) || !s.saveMsg(n, data, types.MethodGroup) {
+		return
+	}
+
 	if s.allServiceRoomMsg(n, data) {
 		s.ack(n, data)
 	}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-im/internal/connect"
+	"go-im/internal/logic/message"
+	"go-im/internal/logic/message/model"
+	"go-im/internal/logic/room/types"
+	"go-im/pkg/errorx"
+)
+
+// 拉取历史消息（传递 to_uid 时拉取私聊消息，否则拉取房间消息）
+func (s *Service) history(n *connect.Node, data *types.Input) {
+	var req types.HistoryReq
+	if err := data.BindData(&req); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodHistory, types.CodeValidateError, "参数格式有误")
+		return
+	}
+
+	conv, ok := s.getConversation(n, data, types.MethodHistory)
+	if !ok {
+		return
+	}
+
+	list, hasMore, err := s.msgService.History(context.Background(), &message.HistoryQuery{
+		Conversation: *conv,
+		BeforeId:     req.BeforeId,
+		Limit:        req.Limit,
+	})
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodHistory, types.CodeError, errorx.Message(err))
+		return
+	}
+
+	s.sendSuccessMsg(n, data.RequestId, types.MethodHistory, types.HistoryResult{
+		List:    s.msgItems(list),
+		HasMore: hasMore,
+	})
+}
+
+// 按序号区间同步消息（传递 to_uid 时同步私聊消息，否则同步房间消息）
+func (s *Service) syncMsg(n *connect.Node, data *types.Input) {
+	var req types.SyncMsgReq
+	if err := data.BindData(&req); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodSyncMsg, types.CodeValidateError, "参数格式有误")
+		return
+	}
+
+	conv, ok := s.getConversation(n, data, types.MethodSyncMsg)
+	if !ok {
+		return
+	}
+
+	list, truncated, err := s.msgService.SyncBySeq(context.Background(), &message.SeqRangeQuery{
+		Conversation: *conv,
+		FromSeq:      req.FromSeq,
+		ToSeq:        req.ToSeq,
+	})
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodSyncMsg, types.CodeError, errorx.Message(err))
+		return
+	}
+
+	s.sendSuccessMsg(n, data.RequestId, types.MethodSyncMsg, types.SyncMsgResult{
+		List:      s.msgItems(list),
+		Truncated: truncated,
+	})
+}
+
+// 获取请求的会话，并校验是否有权限查看
+func (s *Service) getConversation(n *connect.Node, data *types.Input, method types.MsgMethod) (*message.Conversation, bool) {
+	conv := &message.Conversation{UserId: n.UserId}
+
+	if data.ToUid > 0 {
+		conv.PeerUid = data.ToUid
+		return conv, true
+	}
+
+	if !s.isInRoom(n, data.RoomId) {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, "未加入群聊")
+		return nil, false
+	}
+	conv.RoomId = data.RoomId
+	return conv, true
+}
+
+// 转换消息列表
+func (s *Service) msgItems(list []*model.Message) []types.MsgItem {
+	result := make([]types.MsgItem, 0, len(list))
+	for _, msg := range list {
+		result = append(result, types.MsgItem{
+			MsgId:        msg.Id,
+			FromUid:      msg.FromUid,
+			FromUsername: s.userService.UserIdName(msg.FromUid),
+			RoomId:       msg.RoomId,
+			ToUid:        msg.ToUid,
+			Seq:          msg.Seq,
+			Status:       msg.Status,
+			Data:         s.msgContent(msg),
+			SendTime:     msg.CreatedAt.UnixMilli(),
+		})
+	}
+	return result
+}
+
+// 消息内容（已撤回的消息不返回内容）
+func (s *Service) msgContent(msg *model.Message) json.RawMessage {
+	if msg.Status == model.StatusRecalled {
+		return nil
+	}
+	return json.RawMessage(msg.Content)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"go-im/internal/connect"
+	"go-im/internal/logic/room/model"
+	"go-im/internal/logic/room/types"
+	"go-im/pkg/util"
+	"time"
+)
+
+const (
+	inviteCodeLen    = 8             // 邀请码长度
+	inviteUserExpire = 7 * 24 * 3600 // 邀请用户的邀请码有效时长（秒）
+)
+
+// 创建邀请码，持有邀请码的用户可以直接加入房间（包括私有房间及需要审核的房间）
+func (s *Service) createInvite(n *connect.Node, data *types.Input) {
+	r, ok := s.checkPermission(n, data, types.MethodCreateInvite, types.PermInvite)
+	if !ok {
+		return
+	}
+
+	var req types.CreateInviteReq
+	if err := data.BindData(&req); err != nil || req.Expire < 0 || req.MaxUses < 0 {
+		s.sendErrorMsg(n, data.RequestId, types.MethodCreateInvite, types.CodeValidateError, "参数格式有误")
+		return
+	}
+
+	invite, err := s.newInvite(r, n.UserId, req.Expire, req.MaxUses)
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodCreateInvite, types.CodeError, "创建邀请码失败，请稍后再试。")
+		return
+	}
+
+	s.sendSuccessMsg(n, data.RequestId, types.MethodCreateInvite, newInviteInfo(invite))
+}
+
+// 邀请用户加入房间：生成一次性邀请码并通知被邀请的用户（被邀请的用户屏蔽了邀请人时不能邀请）
+func (s *Service) inviteUser(n *connect.Node, data *types.Input) {
+	r, ok := s.checkPermission(n, data, types.MethodInviteUser, types.PermInvite)
+	if !ok {
+		return
+	}
+
+	var req types.InviteUserReq
+	if err := data.BindData(&req); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodInviteUser, types.CodeValidateError, "参数格式有误")
+		return
+	}
+	if req.UserId == 0 || req.UserId == n.UserId || s.userService.UserIdName(req.UserId) == "" {
+		s.sendErrorMsg(n, data.RequestId, types.MethodInviteUser, types.CodeValidateError, "请选择其他用户")
+		return
+	}
+	if s.userService.IsBlocked(context.Background(), req.UserId, n.UserId) {
+		s.sendErrorMsg(n, data.RequestId, types.MethodInviteUser, types.CodeValidateError, "对方已拒绝接收您的邀请")
+		return
+	}
+
+	invite, err := s.newInvite(r, n.UserId, inviteUserExpire, 1)
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodInviteUser, types.CodeError, "邀请失败，请稍后再试。")
+		return
+	}
+
+	info := newInviteInfo(invite)
+	s.sendSuccessMsg(n, data.RequestId, types.MethodInviteUser, info)
+
+	// 通知被邀请的用户，不在线时保存为离线消息
+	s.deliverUserMsg(s.getOutput(n, &types.Input{
+		Data:   types.RoomInviteNotice{InviteInfo: info, RoomName: r.getName()},
+		RoomId: r.RoomId,
+		ToUid:  req.UserId,
+		Method: types.MethodRoomInviteNotice.Uint8(),
+	}).QueueMsgData())
+}
+
+// 创建邀请码，expire 为有效时长（秒），0 表示永久有效
+func (s *Service) newInvite(r *Room, creatorId uint64, expire int64, maxUses int) (*model.RoomInvite, error) {
+	invite := &model.RoomInvite{
+		RoomId:    r.RoomId,
+		Code:      util.RandString(inviteCodeLen),
+		CreatorId: creatorId,
+		MaxUses:   maxUses,
+	}
+	if expire > 0 {
+		invite.ExpireAt = time.Now().Unix() + expire
+	}
+	if err := s.roomInviteRepo.Add(context.Background(), invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+func newInviteInfo(invite *model.RoomInvite) types.InviteInfo {
+	return types.InviteInfo{
+		Code:     invite.Code,
+		RoomId:   invite.RoomId,
+		ExpireAt: invite.ExpireAt,
+		MaxUses:  invite.MaxUses,
+	}
+}
@@ -1,12 +1,40 @@
 package service
 
 import (
+	"context"
 	"go-im/internal/connect"
+	"go-im/internal/logic/room/model"
 	roomType "go-im/internal/logic/room/types"
+	"strings"
 )
 
 // 加入房间
 func (s *Service) join(n *connect.Node, data *roomType.Input) {
+	var req roomType.JoinReq
+	if _, ok := data.Data.(map[string]any); ok {
+		if err := data.BindData(&req); err != nil {
+			s.sendErrorMsg(n, data.RequestId, roomType.MethodJoinRoom, roomType.CodeValidateError, "参数格式有误")
+			return
+		}
+	}
+
+	// 通过邀请码加入时，以邀请码对应的房间为准
+	ctx := context.Background()
+	var invite *model.RoomInvite
+	if code := strings.TrimSpace(req.InviteCode); code != "" {
+		var err error
+		invite, err = s.roomInviteRepo.GetByCode(ctx, code)
+		if err != nil {
+			s.sendErrorMsg(n, data.RequestId, roomType.MethodJoinRoom, roomType.CodeError, "加入房间失败，请稍后再试。")
+			return
+		}
+		if invite == nil {
+			s.sendErrorMsg(n, data.RequestId, roomType.MethodJoinRoom, roomType.CodeValidateError, "邀请码无效")
+			return
+		}
+		data.RoomId = invite.RoomId
+	}
+
 	if data.RoomId == 0 {
 		s.sendErrorMsg(n, data.RequestId, roomType.MethodJoinRoom, roomType.CodeValidateError, "请选择房间或群组")
 		return
@@ -18,6 +46,16 @@ func (s *Service) join(n *connect.Node, data *roomType.Input) {
 		return
 	}
 
+	if expireAt, banned := s.roomBanCache.Get(room.RoomId, n.UserId); banned {
+		s.sendErrorMsg(n, data.RequestId, roomType.MethodJoinRoom, roomType.CodeValidateError, banMsg(expireAt))
+		return
+	}
+
+	if code, msg := s.checkJoinAccess(ctx, room, n.UserId, invite); code != roomType.CodeSuccess {
+		s.sendErrorMsg(n, data.RequestId, roomType.MethodJoinRoom, code, msg)
+		return
+	}
+
 	// 获取用户名称
 	username := s.userService.UserIdName(n.UserId)
 	if username == "" {
@@ -39,6 +77,59 @@ func (s *Service) join(n *connect.Node, data *roomType.Input) {
 
 	s.sendSuccessMsg(n, data.RequestId, roomType.MethodJoinRoom, &roomType.RoomInfo{
 		Id:   room.RoomId,
-		Name: room.name,
+		Name: room.getName(),
 	})
 }
+
+// 校验用户能否加入房间：创建者、房间成员、持有效邀请码的用户可以直接加入；
+// 私有房间必须通过邀请码加入，需要审核的房间必须先申请，房间成员已满时不能加入。校验通过后记录为房间成员
+func (s *Service) checkJoinAccess(ctx context.Context, r *Room, userId uint64, invite *model.RoomInvite) (roomType.Code, string) {
+	if r.getOwnerId() == userId {
+		return roomType.CodeSuccess, ""
+	}
+
+	isMember, err := s.roomMemberRepo.Exists(ctx, r.RoomId, userId)
+	if err != nil {
+		return roomType.CodeError, "加入房间失败，请稍后再试。"
+	}
+	if isMember {
+		return roomType.CodeSuccess, ""
+	}
+
+	if invite == nil {
+		switch {
+		case r.private:
+			return roomType.CodeValidateError, "私有房间需要邀请码才能加入"
+		case r.approval:
+			return roomType.CodeValidateError, "加入该房间需要审核，请先提交申请"
+		}
+	}
+
+	// 检查成员数量、使用邀请码、添加成员在同一个事务中完成
+	code, msg := roomType.CodeSuccess, ""
+	err = s.roomRepo.Transaction(ctx, func(txCtx context.Context) error {
+		full, err := s.isRoomFull(txCtx, r)
+		if err != nil {
+			return err
+		}
+		if full {
+			code, msg = roomType.CodeValidateError, "房间人数已满"
+			return nil
+		}
+		if invite != nil {
+			ok, err := s.roomInviteRepo.Use(txCtx, invite.Id)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				code, msg = roomType.CodeValidateError, "邀请码已过期或使用次数已达上限"
+				return nil
+			}
+		}
+		return s.roomMemberRepo.Add(txCtx, r.RoomId, userId)
+	})
+	if err != nil {
+		return roomType.CodeError, "加入房间失败，请稍后再试。"
+	}
+	return code, msg
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"go-im/internal/connect"
+	"go-im/internal/logic/room/model"
+	"go-im/internal/logic/room/types"
+	"unicode/utf8"
+)
+
+// 申请说明最大长度
+const applyRemarkMaxLen = 100
+
+// 申请加入房间（房间需要审核时），通知房间创建者及管理员
+func (s *Service) applyJoin(n *connect.Node, data *types.Input) {
+	if data.RoomId == 0 {
+		s.sendErrorMsg(n, data.RequestId, types.MethodApplyJoin, types.CodeValidateError, "请选择房间或群组")
+		return
+	}
+
+	var req types.ApplyJoinReq
+	if err := data.BindData(&req); err != nil || utf8.RuneCountInString(req.Remark) > applyRemarkMaxLen {
+		s.sendErrorMsg(n, data.RequestId, types.MethodApplyJoin, types.CodeValidateError, "参数格式有误")
+		return
+	}
+
+	r := s.syncGetRoom(data.RoomId)
+	if r == nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodApplyJoin, types.CodeValidateError, "房间不存在")
+		return
+	}
+	if !r.approval {
+		msg := "该房间无需申请，可直接加入"
+		if r.private {
+			msg = "私有房间需要邀请码才能加入"
+		}
+		s.sendErrorMsg(n, data.RequestId, types.MethodApplyJoin, types.CodeValidateError, msg)
+		return
+	}
+	if expireAt, banned := s.roomBanCache.Get(r.RoomId, n.UserId); banned {
+		s.sendErrorMsg(n, data.RequestId, types.MethodApplyJoin, types.CodeValidateError, banMsg(expireAt))
+		return
+	}
+
+	ctx := context.Background()
+	if r.getOwnerId() == n.UserId {
+		s.sendErrorMsg(n, data.RequestId, types.MethodApplyJoin, types.CodeValidateError, "您已是房间成员")
+		return
+	}
+	isMember, err := s.roomMemberRepo.Exists(ctx, r.RoomId, n.UserId)
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodApplyJoin, types.CodeError, "申请失败，请稍后再试。")
+		return
+	}
+	if isMember {
+		s.sendErrorMsg(n, data.RequestId, types.MethodApplyJoin, types.CodeValidateError, "您已是房间成员")
+		return
+	}
+	pending, err := s.joinRequestRepo.HasPending(ctx, r.RoomId, n.UserId)
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodApplyJoin, types.CodeError, "申请失败，请稍后再试。")
+		return
+	}
+	if pending {
+		s.sendErrorMsg(n, data.RequestId, types.MethodApplyJoin, types.CodeValidateError, "已提交申请，请等待审核")
+		return
+	}
+
+	apply := &model.RoomJoinRequest{
+		RoomId: r.RoomId,
+		UserId: n.UserId,
+		Remark: req.Remark,
+		Status: model.JoinRequestPending,
+	}
+	if err = s.joinRequestRepo.Add(ctx, apply); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodApplyJoin, types.CodeError, "申请失败，请稍后再试。")
+		return
+	}
+
+	item := s.joinRequestItem(apply)
+	s.sendSuccessMsg(n, data.RequestId, types.MethodApplyJoin, item)
+
+	// 通知创建者及管理员
+	for _, uid := range append(s.roomAdmins(r.RoomId), r.getOwnerId()) {
+		s.deliverUserMsg(s.getOutput(n, &types.Input{
+			Data:   item,
+			RoomId: r.RoomId,
+			ToUid:  uid,
+			Method: types.MethodJoinRequestNotice.Uint8(),
+		}).QueueMsgData())
+	}
+}
+
+// 待审核的加入申请列表
+func (s *Service) joinRequestList(n *connect.Node, data *types.Input) {
+	r, ok := s.checkPermission(n, data, types.MethodJoinRequestList, types.PermApproveJoin)
+	if !ok {
+		return
+	}
+
+	list, err := s.joinRequestRepo.ListPending(context.Background(), r.RoomId)
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodJoinRequestList, types.CodeError, "获取申请列表失败，请稍后再试。")
+		return
+	}
+
+	result := make([]types.JoinRequestItem, 0, len(list))
+	for _, apply := range list {
+		result = append(result, s.joinRequestItem(apply))
+	}
+	s.sendSuccessMsg(n, data.RequestId, types.MethodJoinRequestList, result)
+}
+
+// 审核加入申请，通过后申请人成为房间成员，并通知申请人审核结果
+func (s *Service) handleJoinRequest(n *connect.Node, data *types.Input) {
+	r, ok := s.checkPermission(n, data, types.MethodHandleJoinRequest, types.PermApproveJoin)
+	if !ok {
+		return
+	}
+
+	var req types.HandleJoinRequestReq
+	if err := data.BindData(&req); err != nil || req.ApplyId == 0 {
+		s.sendErrorMsg(n, data.RequestId, types.MethodHandleJoinRequest, types.CodeValidateError, "参数格式有误")
+		return
+	}
+
+	ctx := context.Background()
+	apply, err := s.joinRequestRepo.GetById(ctx, req.ApplyId)
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodHandleJoinRequest, types.CodeError, "审核失败，请稍后再试。")
+		return
+	}
+	if apply == nil || apply.RoomId != r.RoomId {
+		s.sendErrorMsg(n, data.RequestId, types.MethodHandleJoinRequest, types.CodeValidateError, "申请不存在")
+		return
+	}
+
+	status := model.JoinRequestRejected
+	if req.Approve {
+		status = model.JoinRequestApproved
+	}
+
+	// 检查成员数量、更新申请状态、添加成员在同一个事务中完成
+	var failMsg string
+	err = s.roomRepo.Transaction(ctx, func(txCtx context.Context) error {
+		if req.Approve {
+			// 申请后被禁止加入的用户不能通过审核
+			if _, banned := s.roomBanCache.Get(r.RoomId, apply.UserId); banned {
+				failMsg = "该用户已被禁止加入房间"
+				return nil
+			}
+			full, err := s.isRoomFull(txCtx, r)
+			if err != nil {
+				return err
+			}
+			if full {
+				failMsg = "房间人数已满"
+				return nil
+			}
+		}
+
+		ok, err := s.joinRequestRepo.Handle(txCtx, apply.Id, status, n.UserId)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			failMsg = "该申请已被处理"
+			return nil
+		}
+		if req.Approve {
+			return s.roomMemberRepo.Add(txCtx, r.RoomId, apply.UserId)
+		}
+		return nil
+	})
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodHandleJoinRequest, types.CodeError, "审核失败，请稍后再试。")
+		return
+	}
+	if failMsg != "" {
+		s.sendErrorMsg(n, data.RequestId, types.MethodHandleJoinRequest, types.CodeValidateError, failMsg)
+		return
+	}
+
+	result := types.JoinRequestResult{
+		ApplyId:  apply.Id,
+		RoomId:   r.RoomId,
+		RoomName: r.getName(),
+		Approve:  req.Approve,
+	}
+	s.sendSuccessMsg(n, data.RequestId, types.MethodHandleJoinRequest, result)
+
+	// 通知申请人
+	s.deliverUserMsg(s.getOutput(n, &types.Input{
+		Data:   result,
+		RoomId: r.RoomId,
+		ToUid:  apply.UserId,
+		Method: types.MethodJoinRequestResult.Uint8(),
+	}).QueueMsgData())
+}
+
+// 加入申请信息
+func (s *Service) joinRequestItem(apply *model.RoomJoinRequest) types.JoinRequestItem {
+	return types.JoinRequestItem{
+		Id:        apply.Id,
+		RoomId:    apply.RoomId,
+		UserId:    apply.UserId,
+		Username:  s.userService.UserIdName(apply.UserId),
+		Remark:    apply.Remark,
+		CreatedAt: apply.CreatedAt.Unix(),
+	}
+}
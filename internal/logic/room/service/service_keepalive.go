@@ -0,0 +1,34 @@
+package service
+
+import (
+	"go-im/pkg/logger"
+	"go.uber.org/zap"
+	"time"
+)
+
+// 服务心跳，心跳过期后该服务记录的在线用户视为离线
+const (
+	keepAlivePeriod = 10 * time.Second
+	keepAliveTTL    = 3 * keepAlivePeriod
+)
+
+// KeepAlive 定时刷新当前服务心跳，并清除已退出服务遗留的在线状态
+func (s *Service) KeepAlive(serverId string) {
+	s.serverId = serverId
+	s.keepAlive()
+
+	go s.userOnlineCache.ClearDead()
+	go func() {
+		ticker := time.NewTicker(keepAlivePeriod)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.keepAlive()
+		}
+	}()
+}
+
+func (s *Service) keepAlive() {
+	if err := s.userOnlineCache.KeepAlive(s.serverId, keepAliveTTL); err != nil {
+		logger.Error("server keep alive error", zap.String("server_id", s.serverId), zap.Error(err))
+	}
+}
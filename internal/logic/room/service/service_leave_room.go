@@ -7,17 +7,36 @@ import (
 
 // 离开房间
 func (s *Service) leaveRoom(n *connect.Node, data *roomType.Input) {
+	if !n.InRoom(data.RoomId) {
+		s.sendErrorMsg(n, data.RequestId, roomType.MethodOffline, roomType.CodeValidateError, "未加入群聊")
+		return
+	}
+
+	s.leave(n, data.RoomId)
+
+	s.sendSuccessMsg(n, data.RequestId, roomType.MethodOffline, roomType.UserItem{
+		Id:   n.UserId,
+		Name: s.userService.UserIdName(n.UserId),
+	})
+}
+
+// 离开指定房间
+func (s *Service) leave(n *connect.Node, roomId uint64) {
 	// 下线广播
-	s.offlineNotify(n)
+	s.offlineNotify(n, roomId)
 
-	// 从房间中删除连接（这里会将链接的 roomId 重置为0，因此要放到最后）
-	if room := s.getRoom(n.RoomId); room != nil {
+	// 从房间中删除连接
+	if room := s.getRoom(roomId); room != nil {
 		s.handleLeaveRoom(room, n)
+		return
 	}
+	s.roomUserCache.Remove(roomId, n.UserId)
+	s.userServiceCache.Remove(roomId, n.UserId)
+	n.LeaveRoom(roomId)
 }
 
 // 下线广播（不能通过 chan 通知，因为关闭客户端时已将相关 chan 关闭）
-func (s *Service) offlineNotify(n *connect.Node) {
+func (s *Service) offlineNotify(n *connect.Node, roomId uint64) {
 	name := s.userService.UserIdName(n.UserId)
 	data := roomType.Output{
 		Method: roomType.MethodOffline,
@@ -25,7 +44,7 @@ func (s *Service) offlineNotify(n *connect.Node) {
 			Id:   n.UserId,
 			Name: name,
 		},
-		RoomId:     n.RoomId,
+		RoomId:     roomId,
 		FromServer: n.ServerId,
 	}
 
@@ -33,7 +52,7 @@ func (s *Service) offlineNotify(n *connect.Node) {
 	connect.SendGatewayMsg(data.Marshal())
 
 	// 推送当前服务指定房间的全部用户
-	if room := s.getRoom(n.RoomId); room != nil {
+	if room := s.getRoom(roomId); room != nil {
 		s.pushRoom(room, data.QueueMsgData())
 	}
 }
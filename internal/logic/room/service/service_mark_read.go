@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"go-im/internal/connect"
+	"go-im/internal/logic/room/types"
+	"go-im/pkg/errorx"
+)
+
+// 标记会话已读（传递 to_uid 时标记私聊会话，并通知对方已读）
+func (s *Service) markRead(n *connect.Node, data *types.Input) {
+	var req types.MarkReadReq
+	if err := data.BindData(&req); err != nil || req.MsgId == 0 {
+		s.sendErrorMsg(n, data.RequestId, types.MethodMarkRead, types.CodeValidateError, "参数格式有误")
+		return
+	}
+
+	conv, ok := s.getConversation(n, data, types.MethodMarkRead)
+	if !ok {
+		return
+	}
+
+	msg, err := s.msgService.MarkRead(context.Background(), conv, req.MsgId)
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodMarkRead, types.CodeValidateError, errorx.Message(err))
+		return
+	}
+
+	s.sendSuccessMsg(n, data.RequestId, types.MethodMarkRead, nil)
+
+	// 私聊已读回执（只通知消息发送方）
+	if conv.PeerUid > 0 && msg.FromUid == conv.PeerUid {
+		s.deliverUserMsg(&types.QueueMsgData{
+			Method:  types.MethodReadReceipt,
+			Code:    types.CodeSuccess,
+			FromUid: n.UserId,
+			ToUid:   conv.PeerUid,
+			Data: types.ReadReceipt{
+				UserId: n.UserId,
+				MsgId:  msg.Id,
+				Seq:    msg.Seq,
+			},
+			FromServer: n.ServerId,
+		})
+	}
+}
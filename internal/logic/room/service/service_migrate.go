@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"go-im/internal/logic/room/model"
+	"go-im/pkg/logger"
+	"unicode/utf8"
+)
+
+// MigrateLegacyRooms 将旧版本只保存在 Redis 中的房间写入数据库（旧版本房间id即创建者id，缓存值为房间名称），
+// 创建者同时写入房间成员，然后删除旧格式的缓存，读取时从数据库回写。多个服务启动时重复执行不影响；
+// 房间id已被其他房间占用时不做处理，保留旧格式的缓存并记录错误日志
+func (s *Service) MigrateLegacyRooms() {
+	legacy, err := s.roomCache.Legacy()
+	if err != nil {
+		logger.Errorf("load legacy rooms error: %v", err)
+		return
+	}
+	if len(legacy) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	migrated := 0
+	for roomId, name := range legacy {
+		if utf8.RuneCountInString(name) > roomNameMaxLen {
+			name = string([]rune(name)[:roomNameMaxLen])
+		}
+
+		conflict := false
+		err = s.roomRepo.Transaction(ctx, func(txCtx context.Context) error {
+			inserted, err := s.roomRepo.Restore(txCtx, &model.Room{Id: roomId, OwnerId: roomId, Name: name})
+			if err != nil {
+				return err
+			}
+			if !inserted {
+				// 房间id已存在：其他服务已迁移过该房间时继续处理，否则房间id已被新创建的房间占用
+				roomModel, err := s.roomRepo.GetById(txCtx, roomId)
+				if err != nil {
+					return err
+				}
+				if roomModel == nil || roomModel.OwnerId != roomId || roomModel.Name != name {
+					conflict = true
+					return nil
+				}
+			}
+			return s.roomMemberRepo.Add(txCtx, roomId, roomId)
+		})
+		if err != nil {
+			logger.Errorf("migrate legacy room error: %v, room_id: %d", err, roomId)
+			continue
+		}
+		if conflict {
+			// 保留旧格式的缓存，由人工处理
+			logger.Errorf("migrate legacy room conflict: room id is used by another room, room_id: %d, name: %s", roomId, name)
+			continue
+		}
+		s.roomCache.Remove(roomId)
+		migrated++
+	}
+	logger.Infof("migrate legacy rooms: %d/%d", migrated, len(legacy))
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go-im/internal/connect"
+	"go-im/internal/logic/room/repo"
+	"go-im/internal/logic/room/types"
+	"time"
+)
+
+// 移出成员
+func (s *Service) kick(n *connect.Node, data *types.Input) {
+	r, ok := s.checkPermission(n, data, types.MethodKick, types.PermKick)
+	if !ok {
+		return
+	}
+
+	var req types.KickReq
+	if err := data.BindData(&req); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodKick, types.CodeValidateError, "参数格式有误")
+		return
+	}
+	if !s.checkTargetMember(n, data, types.MethodKick, r, req.UserId, true) {
+		return
+	}
+
+	s.moderate(n, data, r, types.MethodKick, types.ModerateEvent{Action: types.ModerateKick, UserId: req.UserId})
+}
+
+// 禁止/允许成员加入房间，禁止时会将成员移出房间
+func (s *Service) ban(n *connect.Node, data *types.Input) {
+	s.restrict(n, data, types.MethodBan, types.PermKick, s.roomBanCache, types.ModerateBan, types.ModerateUnban)
+}
+
+// 禁言/解除禁言
+func (s *Service) mute(n *connect.Node, data *types.Input) {
+	s.restrict(n, data, types.MethodMute, types.PermMute, s.roomMuteCache, types.ModerateMute, types.ModerateUnmute)
+}
+
+// 设置/解除成员限制
+func (s *Service) restrict(n *connect.Node, data *types.Input, method types.MsgMethod, perm types.Permission,
+	cache *repo.RoomRestrictCache, action, cancelAction string) {
+	r, ok := s.checkPermission(n, data, method, perm)
+	if !ok {
+		return
+	}
+
+	var req types.RestrictReq
+	if err := data.BindData(&req); err != nil || req.Duration < 0 {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, "参数格式有误")
+		return
+	}
+	// 禁止加入房间时，用户可以不在房间中
+	if !s.checkTargetMember(n, data, method, r, req.UserId, action == types.ModerateMute) {
+		return
+	}
+
+	event := types.ModerateEvent{Action: cancelAction, UserId: req.UserId}
+	var err error
+	if req.Cancel {
+		err = cache.Remove(r.RoomId, req.UserId)
+	} else {
+		event.Action = action
+		if req.Duration > 0 {
+			event.ExpireAt = time.Now().Unix() + req.Duration
+		}
+		err = cache.Set(r.RoomId, req.UserId, event.ExpireAt)
+	}
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeError, "操作失败，请稍后再试。")
+		return
+	}
+
+	s.moderate(n, data, r, method, event)
+}
+
+// 通知房间成员（包括其他服务），需要移出房间时，由成员连接所在的服务处理
+func (s *Service) moderate(n *connect.Node, data *types.Input, r *Room, method types.MsgMethod, event types.ModerateEvent) {
+	if event.IsEvict() {
+		s.roomUserCache.Remove(r.RoomId, event.UserId)
+		s.userServiceCache.Remove(r.RoomId, event.UserId)
+		// 删除成员记录的同时删除了成员角色
+		_ = s.roomMemberRepo.Remove(context.Background(), r.RoomId, event.UserId)
+		s.removeRoleCache(r.RoomId)
+	}
+
+	s.sendSuccessMsg(n, data.RequestId, method, event)
+
+	// 先通知再移出，被移出的用户也能收到通知
+	out := s.getOutput(n, &types.Input{
+		Data:   event,
+		RoomId: r.RoomId,
+		Method: types.MethodModerateNotice.Uint8(),
+	}).QueueMsgData()
+	n.BroadcastQueue <- out.Marshal()
+	s.pushRoom(r, out)
+
+	if event.IsEvict() {
+		s.evictMember(r.RoomId, event.UserId)
+	}
+}
+
+// 处理其他服务的成员管理通知
+func (s *Service) handleModerate(data *types.QueueMsgData) {
+	s.SendRoomMsg(data.RoomId, data)
+
+	var event types.ModerateEvent
+	if err := data.BindData(&event); err == nil && event.IsEvict() {
+		s.evictMember(data.RoomId, event.UserId)
+	}
+}
+
+// 将当前服务的用户连接移出房间
+func (s *Service) evictMember(roomId, userId uint64) {
+	if r := s.getRoom(roomId); r != nil {
+		s.unsubscribeRoom(r, userId)
+	}
+}
+
+// 禁言提示
+func muteMsg(expireAt int64) string {
+	if expireAt == 0 {
+		return "您已被禁言"
+	}
+	return fmt.Sprintf("您已被禁言，解除时间：%s", time.Unix(expireAt, 0).Format(time.DateTime))
+}
+
+// 禁止加入房间提示
+func banMsg(expireAt int64) string {
+	if expireAt == 0 {
+		return "您已被禁止加入该房间"
+	}
+	return fmt.Sprintf("您已被禁止加入该房间，解除时间：%s", time.Unix(expireAt, 0).Format(time.DateTime))
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"go-im/internal/connect"
+	"go-im/internal/logic/message/model"
+	"go-im/internal/logic/room/types"
+	"go-im/pkg/errorx"
+)
+
+// 撤回消息（消息发送者或房间创建者）
+func (s *Service) recall(n *connect.Node, data *types.Input) {
+	msg, ok := s.getModifiableMsg(n, data, types.MethodRecall, true)
+	if !ok {
+		return
+	}
+
+	if err := s.msgService.Recall(context.Background(), msg); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodRecall, types.CodeValidateError, errorx.Message(err))
+		return
+	}
+
+	s.sendSuccessMsg(n, data.RequestId, types.MethodRecall, nil)
+	s.notifyModifyMsg(n, msg, types.MethodRecall, types.ModifyMsgEvent{
+		MsgId: msg.Id,
+		Seq:   msg.Seq,
+	})
+}
+
+// 编辑消息（只有消息发送者可以编辑）
+func (s *Service) edit(n *connect.Node, data *types.Input) {
+	msg, ok := s.getModifiableMsg(n, data, types.MethodEdit, false)
+	if !ok {
+		return
+	}
+
+	var req types.ModifyMsgReq
+	_ = data.BindData(&req)
+	content, err := types.ParseMsgContent(req.Data, contentLimit())
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodEdit, types.CodeValidateError, err.Error())
+		return
+	}
+
+	if err = s.msgService.Edit(context.Background(), msg, content); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodEdit, types.CodeValidateError, errorx.Message(err))
+		return
+	}
+
+	s.sendSuccessMsg(n, data.RequestId, types.MethodEdit, nil)
+	s.notifyModifyMsg(n, msg, types.MethodEdit, types.ModifyMsgEvent{
+		MsgId: msg.Id,
+		Seq:   msg.Seq,
+		Data:  content,
+	})
+}
+
+// 获取要修改的消息，并校验操作权限。allowManager 为 true 时，房间创建者及管理员可以修改房间内的全部消息
+func (s *Service) getModifiableMsg(n *connect.Node, data *types.Input, method types.MsgMethod, allowManager bool) (*model.Message, bool) {
+	var req types.ModifyMsgReq
+	if err := data.BindData(&req); err != nil || req.MsgId == 0 {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, "参数格式有误")
+		return nil, false
+	}
+
+	msg, err := s.msgService.GetMsg(context.Background(), req.MsgId)
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, errorx.Message(err))
+		return nil, false
+	}
+
+	if msg.MsgType == model.TypeGroup && !s.isInRoom(n, msg.RoomId) {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, "未加入群聊")
+		return nil, false
+	}
+
+	isManager := allowManager && msg.MsgType == model.TypeGroup && s.hasPermission(msg.RoomId, n.UserId, types.PermRecallMsg)
+	if msg.FromUid != n.UserId && !isManager {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, "无权操作该消息")
+		return nil, false
+	}
+
+	return msg, true
+}
+
+// 通知会话中的其他用户（群聊通知全部服务的房间用户，私聊通知对方）
+func (s *Service) notifyModifyMsg(n *connect.Node, msg *model.Message, method types.MsgMethod, event types.ModifyMsgEvent) {
+	input := &types.Input{
+		Method: method.Uint8(),
+		Data:   event,
+		RoomId: msg.RoomId,
+	}
+
+	if msg.MsgType == model.TypeGroup {
+		s.allServiceRoomMsg(n, input)
+		return
+	}
+
+	// 私聊消息只有发送者可以修改，因此通知接收者
+	input.ToUid = msg.ToUid
+	s.deliverUserMsg(s.getOutput(n, input).QueueMsgData())
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"go-im/internal/connect"
 	roomType "go-im/internal/logic/room/types"
 )
@@ -17,6 +18,30 @@ func (s *Service) normal(n *connect.Node, data *roomType.Input) {
 		return
 	}
 
-	s.sendErrorMsg(n, data.RequestId, roomType.MethodNormal, roomType.CodeError, "目前只支持群聊，暂不支持私聊")
-	return
+	if data.ToUid == n.UserId {
+		s.sendErrorMsg(n, data.RequestId, roomType.MethodNormal, roomType.CodeValidateError, "不能给自己发送消息")
+		return
+	}
+
+	// 接收者不存在
+	if s.userService.UserIdName(data.ToUid) == "" {
+		s.sendErrorMsg(n, data.RequestId, roomType.MethodNormal, roomType.CodeValidateError, "接收消息的用户不存在")
+		return
+	}
+
+	// 接收者已屏蔽发送者
+	if s.userService.IsBlocked(context.Background(), data.ToUid, n.UserId) {
+		s.sendErrorMsg(n, data.RequestId, roomType.MethodNormal, roomType.CodeValidateError, "对方已拒绝接收您的消息")
+		return
+	}
+
+	// 私聊消息不属于任何房间
+	data.RoomId = 0
+
+	if !s.parseContent(n, data, roomType.MethodNormal) || !s.saveMsg(n, data, roomType.MethodNormal) {
+		return
+	}
+
+	s.sendUserMsg(n, data)
+	s.ack(n, data)
 }
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"encoding/json"
+	"go-im/config"
+	"go-im/internal/connect"
+	"go-im/internal/logic/room/types"
+	"go-im/pkg/logger"
+	"go.uber.org/zap"
+	"time"
+)
+
+/**
+ * @Description: 离线消息（私聊消息接收者不在线时保存，用户上线后按顺序投递）
+ */
+
+// 保存离线消息
+func (s *Service) saveOfflineMsg(userId uint64, data *types.QueueMsgData) {
+	conf := config.C.Message
+	s.offlineMsgCache.Push(userId, data.Marshal(), conf.OfflineLimit, time.Duration(conf.OfflineExpire)*time.Second)
+}
+
+// 投递离线消息
+func (s *Service) pushOfflineMsg(n *connect.Node) {
+	for _, msg := range s.offlineMsgCache.PopAll(n.UserId) {
+		var data = new(types.QueueMsgData)
+		if err := json.Unmarshal(msg, data); err != nil {
+			logger.Error("offline msg unmarshal error", zap.Uint64("user_id", n.UserId), zap.Error(err))
+			continue
+		}
+		s.pushUser(n, data)
+	}
+}
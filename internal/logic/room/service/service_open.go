@@ -0,0 +1,13 @@
+package service
+
+import (
+	"go-im/internal/connect"
+)
+
+// 处理连接建立
+func (s *Service) Open(n *connect.Node) {
+	s.userOnlineCache.Online(n.UserId, n.ServerId)
+
+	// 投递离线消息
+	s.pushOfflineMsg(n)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"go-im/internal/connect"
+	"go-im/internal/logic/room/types"
+	"go-im/pkg/logger"
+)
+
+/**
+ * @Description: 房间成员角色及操作权限
+ */
+
+// 获取用户在房间中的角色
+func (s *Service) roomRole(r *Room, userId uint64) types.Role {
+	if r.getOwnerId() == userId {
+		return types.RoleOwner
+	}
+	return s.roomRoles(r.RoomId)[userId]
+}
+
+// 获取房间管理员
+func (s *Service) roomAdmins(roomId uint64) []uint64 {
+	roles := s.roomRoles(roomId)
+	ids := make([]uint64, 0, len(roles))
+	for uid, role := range roles {
+		if role == types.RoleAdmin {
+			ids = append(ids, uid)
+		}
+	}
+	return ids
+}
+
+// 获取房间成员角色（不包含普通成员及创建者），优先从缓存获取，缓存不存在时从数据库加载并回写缓存。
+// 查询失败时返回空结果，即全部按普通成员处理
+func (s *Service) roomRoles(roomId uint64) map[uint64]types.Role {
+	if roles, ok := s.roomRoleCache.Get(roomId); ok {
+		return roles
+	}
+
+	admins, err := s.roomMemberRepo.Admins(context.Background(), roomId)
+	if err != nil {
+		return nil
+	}
+	roles := make(map[uint64]types.Role, len(admins))
+	for _, uid := range admins {
+		roles[uid] = types.RoleAdmin
+	}
+	if err = s.roomRoleCache.Set(roomId, roles); err != nil {
+		logger.Errorf("set room role cache error: %v", err)
+	}
+	return roles
+}
+
+// 删除房间角色缓存（角色变更后调用，下次读取时从数据库重新加载）
+func (s *Service) removeRoleCache(roomId uint64) {
+	if err := s.roomRoleCache.DeleteRoom(roomId); err != nil {
+		logger.Errorf("remove room role cache error: %v, room_id: %d", err, roomId)
+	}
+}
+
+// 用户是否拥有房间的操作权限
+func (s *Service) hasPermission(roomId, userId uint64, perm types.Permission) bool {
+	r := s.syncGetRoom(roomId)
+	return r != nil && s.roomRole(r, userId).Can(perm)
+}
+
+// 校验用户对房间的操作权限，校验失败时发送错误消息
+func (s *Service) checkPermission(n *connect.Node, data *types.Input, method types.MsgMethod, perm types.Permission) (*Room, bool) {
+	if data.RoomId == 0 {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, "请选择房间或群组")
+		return nil, false
+	}
+
+	r := s.syncGetRoom(data.RoomId)
+	if r == nil {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, "房间不存在")
+		return nil, false
+	}
+
+	if !s.roomRole(r, n.UserId).Can(perm) {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, "无权进行该操作")
+		return nil, false
+	}
+
+	return r, true
+}
+
+// 校验被操作的成员：操作者已加入房间、不能操作自己、被操作者角色必须低于操作者。mustJoined 为 true 时被操作者必须是房间成员
+func (s *Service) checkTargetMember(n *connect.Node, data *types.Input, method types.MsgMethod, r *Room, targetId uint64, mustJoined bool) bool {
+	if !s.isInRoom(n, r.RoomId) {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, "未加入群聊")
+		return false
+	}
+	if targetId == 0 || targetId == n.UserId {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, "请选择其他成员")
+		return false
+	}
+	if mustJoined {
+		// 以持久化的成员关系为准，不在线的成员也可以操作
+		isMember, err := s.roomMemberRepo.Exists(context.Background(), r.RoomId, targetId)
+		if err != nil {
+			s.sendErrorMsg(n, data.RequestId, method, types.CodeError, "操作失败，请稍后再试。")
+			return false
+		}
+		if !isMember {
+			s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, "该用户不是房间成员")
+			return false
+		}
+	}
+	if !s.roomRole(r, n.UserId).Over(s.roomRole(r, targetId)) {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, "无权操作该成员")
+		return false
+	}
+	return true
+}
+
+// 通知房间成员角色变更（包括其他服务）
+func (s *Service) notifyRoleChange(n *connect.Node, r *Room, events []types.RoleChangeEvent) {
+	s.allServiceRoomMsg(n, &types.Input{
+		Data:   events,
+		RoomId: r.RoomId,
+		Method: types.MethodRoleChangeNotice.Uint8(),
+	})
+}
+
+// 同步其他服务发起的角色变更（更新本地房间的创建者）
+func (s *Service) applyRoleChange(data *types.QueueMsgData) {
+	r := s.getRoom(data.RoomId)
+	if r == nil {
+		return
+	}
+
+	var events []types.RoleChangeEvent
+	if err := data.BindData(&events); err != nil {
+		return
+	}
+	for _, event := range events {
+		if event.Role == types.RoleOwner {
+			r.setOwnerId(event.UserId)
+		}
+	}
+}
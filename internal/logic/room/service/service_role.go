@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"go-im/internal/connect"
+	"go-im/internal/logic/room/types"
+)
+
+// 设置/取消管理员，仅房间创建者可操作
+func (s *Service) setAdmin(n *connect.Node, data *types.Input) {
+	r, ok := s.checkPermission(n, data, types.MethodSetAdmin, types.PermSetAdmin)
+	if !ok {
+		return
+	}
+
+	var req types.SetAdminReq
+	if err := data.BindData(&req); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodSetAdmin, types.CodeValidateError, "参数格式有误")
+		return
+	}
+	if !s.checkTargetMember(n, data, types.MethodSetAdmin, r, req.UserId, true) {
+		return
+	}
+
+	role := types.RoleMember
+	if req.Admin {
+		role = types.RoleAdmin
+	}
+	if err := s.roomMemberRepo.SetRole(context.Background(), r.RoomId, req.UserId, role); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodSetAdmin, types.CodeError, "设置失败，请稍后再试。")
+		return
+	}
+	s.removeRoleCache(r.RoomId)
+
+	event := types.RoleChangeEvent{UserId: req.UserId, Role: role}
+	s.sendSuccessMsg(n, data.RequestId, types.MethodSetAdmin, event)
+	s.notifyRoleChange(n, r, []types.RoleChangeEvent{event})
+}
+
+// 转让房间，原创建者成为管理员
+func (s *Service) transferOwner(n *connect.Node, data *types.Input) {
+	r, ok := s.checkPermission(n, data, types.MethodTransferOwner, types.PermTransfer)
+	if !ok {
+		return
+	}
+
+	var req types.TransferOwnerReq
+	if err := data.BindData(&req); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodTransferOwner, types.CodeValidateError, "参数格式有误")
+		return
+	}
+	if !s.checkTargetMember(n, data, types.MethodTransferOwner, r, req.UserId, true) {
+		return
+	}
+
+	// 变更创建者及双方角色在同一个事务中完成
+	ctx := context.Background()
+	var updated bool
+	err := s.roomRepo.Transaction(ctx, func(txCtx context.Context) error {
+		var err error
+		if updated, err = s.roomRepo.UpdateOwner(txCtx, r.RoomId, n.UserId, req.UserId); err != nil || !updated {
+			return err
+		}
+		if err = s.roomMemberRepo.SetRole(txCtx, r.RoomId, req.UserId, types.RoleMember); err != nil {
+			return err
+		}
+		// 原创建者成为管理员
+		return s.roomMemberRepo.SetRole(txCtx, r.RoomId, n.UserId, types.RoleAdmin)
+	})
+	if err != nil || !updated {
+		s.sendErrorMsg(n, data.RequestId, types.MethodTransferOwner, types.CodeError, "转让失败，请稍后再试。")
+		return
+	}
+
+	r.setOwnerId(req.UserId)
+	if roomModel, _ := s.roomRepo.GetById(ctx, r.RoomId); roomModel != nil {
+		s.setRoomCache(roomModel)
+	} else {
+		s.roomCache.Remove(r.RoomId)
+	}
+	s.removeRoleCache(r.RoomId)
+
+	events := []types.RoleChangeEvent{
+		{UserId: req.UserId, Role: types.RoleOwner},
+		{UserId: n.UserId, Role: types.RoleAdmin},
+	}
+	s.sendSuccessMsg(n, data.RequestId, types.MethodTransferOwner, events)
+	s.notifyRoleChange(n, r, events)
+}
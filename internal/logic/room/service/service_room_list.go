@@ -1,29 +1,77 @@
 package service
 
 import (
+	"context"
 	"go-im/internal/connect"
+	"go-im/internal/logic/room"
 	"go-im/internal/logic/room/types"
-	"go-im/pkg/logger"
-	"go-im/pkg/util"
-	"go.uber.org/zap"
+	"strings"
+	"unicode/utf8"
 )
 
-// 房间列表
+// 房间列表（以数据库为准，缓存丢失不影响房间列表），支持按名称搜索、筛选、排序及分页
 func (s *Service) roomList(n *connect.Node, data *types.Input) {
-	list := s.roomCache.List()
+	var req types.RoomListReq
+	if err := data.BindData(&req); err != nil || req.Page < 0 || req.Size < 0 {
+		s.sendErrorMsg(n, data.RequestId, types.MethodRoomList, types.CodeValidateError, "参数格式有误")
+		return
+	}
+	req.Keyword = strings.TrimSpace(req.Keyword)
+	if utf8.RuneCountInString(req.Keyword) > roomNameMaxLen {
+		s.sendErrorMsg(n, data.RequestId, types.MethodRoomList, types.CodeValidateError, "搜索关键字过长")
+		return
+	}
+	if req.Sort != "" && req.Sort != room.SortCreated && req.Sort != room.SortMembers {
+		s.sendErrorMsg(n, data.RequestId, types.MethodRoomList, types.CodeValidateError, "不支持的排序方式")
+		return
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Size == 0 {
+		req.Size = room.ListDefaultSize
+	} else if req.Size > room.ListMaxSize {
+		req.Size = room.ListMaxSize
+	}
+
+	ctx := context.Background()
+	list, total, err := s.roomRepo.List(ctx, &room.ListQuery{
+		UserId:  n.UserId,
+		Keyword: req.Keyword,
+		OwnerId: req.OwnerId,
+		Joined:  req.Joined,
+		Sort:    req.Sort,
+		Desc:    req.Desc,
+		Page:    req.Page,
+		Size:    req.Size,
+	})
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodRoomList, types.CodeError, "获取房间列表失败，请稍后再试。")
+		return
+	}
+
+	var (
+		result  = make(types.RoomList, 0, len(list))
+		roomIds = make([]uint64, 0, len(list))
+	)
+	for _, item := range list {
+		roomIds = append(roomIds, item.Id)
+		result = append(result, newRoomInfo(item))
+	}
 
-	var result = types.RoomList{}
-	for id, name := range list {
-		roomId, err := util.StringToUint64(id)
-		if err != nil {
-			logger.Error("roomId parse error", zap.Error(err))
-			continue
-		}
-		result = append(result, types.RoomInfo{
-			Id:   roomId,
-			Name: name,
-		})
+	// 成员数量，查询失败不影响房间列表
+	members, _ := s.roomMemberRepo.CountByRooms(ctx, roomIds)
+	// 未读消息数量
+	unread := s.msgService.RoomUnread(ctx, n.UserId, roomIds)
+	for i := range result {
+		result[i].Members = members[result[i].Id]
+		result[i].Unread = unread[result[i].Id]
 	}
 
-	s.sendSuccessMsg(n, data.RequestId, types.MethodRoomList, result)
+	s.sendSuccessMsg(n, data.RequestId, types.MethodRoomList, types.RoomPage{
+		List:  result,
+		Total: total,
+		Page:  req.Page,
+		Size:  req.Size,
+	})
 }
@@ -1,10 +1,13 @@
 package service
 
 import (
+	"context"
+	"go-im/config"
 	"go-im/internal/connect"
+	"go-im/internal/logic/room/model"
+	"go-im/internal/logic/room/repo"
 	roomType "go-im/internal/logic/room/types"
 	"go-im/pkg/logger"
-	"go-im/pkg/util"
 )
 
 /**
@@ -12,18 +15,22 @@ import (
  */
 
 // 新建房间
-func (s *Service) newRoom(roomId uint64, name string) *Room {
-	s.newRoomLock.Lock()
-	defer s.newRoomLock.Unlock()
+func (s *Service) newRoom(roomId uint64, info *repo.RoomCacheItem) *Room {
+	s.roomsLock.Lock()
+	defer s.roomsLock.Unlock()
 
-	if r := s.getRoom(roomId); r != nil {
+	if r, ok := s.roomsManager[roomId]; ok {
 		return r
 	}
 
 	r := &Room{
-		RoomId:  roomId,
-		name:    name,
-		clients: make(map[uint64]*connect.Node),
+		RoomId:     roomId,
+		ownerId:    info.OwnerId,
+		name:       info.Name,
+		private:    info.Private,
+		approval:   info.JoinApproval,
+		maxMembers: info.MaxMembers,
+		clients:    make(map[uint64]*connect.Node),
 	}
 	s.roomsManager[roomId] = r
 	return r
@@ -31,6 +38,9 @@ func (s *Service) newRoom(roomId uint64, name string) *Room {
 
 // 获取房间
 func (s *Service) getRoom(roomId uint64) *Room {
+	s.roomsLock.RLock()
+	defer s.roomsLock.RUnlock()
+
 	r, ok := s.roomsManager[roomId]
 	if ok {
 		return r
@@ -39,39 +49,81 @@ func (s *Service) getRoom(roomId uint64) *Room {
 	return nil
 }
 
-// 同步获取房间（本地 map 不存在，检查缓存中是否存在）
+// 同步获取房间（本地 map 不存在，依次从缓存、数据库中获取）
 func (s *Service) syncGetRoom(roomId uint64) *Room {
 	if r := s.getRoom(roomId); r != nil {
 		return r
 	}
 
 	// 本地不存在，从Redis中获取
-	if name := s.roomCache.GetName(roomId); name != "" {
-		return s.newRoom(roomId, name)
+	if item := s.roomCache.Get(roomId); item != nil {
+		return s.newRoom(roomId, item)
 	}
 
-	return nil
+	// 缓存不存在，从数据库中获取并回写缓存
+	roomModel, err := s.roomRepo.GetById(context.Background(), roomId)
+	if err != nil || roomModel == nil || !roomModel.IsNormal() {
+		return nil
+	}
+	s.setRoomCache(roomModel)
+
+	return s.newRoom(roomId, roomCacheItem(roomModel))
+}
+
+// 缓存房间信息，缓存写入失败不影响业务，读取时会从数据库回写
+func (s *Service) setRoomCache(roomModel *model.Room) {
+	if err := s.roomCache.Set(roomModel.Id, roomCacheItem(roomModel)); err != nil {
+		logger.Errorf("set room cache error: %v", err)
+	}
+}
+
+// 房间缓存信息
+func roomCacheItem(roomModel *model.Room) *repo.RoomCacheItem {
+	return &repo.RoomCacheItem{
+		Name:         roomModel.Name,
+		OwnerId:      roomModel.OwnerId,
+		Private:      roomModel.IsPrivate(),
+		JoinApproval: roomModel.JoinApproval,
+		MaxMembers:   roomModel.MaxMembers,
+	}
 }
 
 // 加入房间
 func (s *Service) joinRoom(r *Room, n *connect.Node, username string) {
-	r.joinLock.Lock()
-	defer r.joinLock.Unlock()
-
 	s.roomUserCache.Create(r.RoomId, n.UserId, username)
 	s.userServiceCache.Create(r.RoomId, n.UserId, n.ServerAddr)
 
-	if _, ok := r.clients[n.UserId]; !ok {
-		r.clients[n.UserId] = n
+	s.subscribeRoom(r, n)
+}
+
+// 连接订阅房间
+func (s *Service) subscribeRoom(r *Room, n *connect.Node) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.clients[n.UserId] = n
+	n.JoinRoom(r.RoomId)
+}
+
+// 连接取消订阅房间，返回连接是否在房间中
+func (s *Service) unsubscribeRoom(r *Room, userId uint64) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	node, ok := r.clients[userId]
+	if !ok {
+		return false
 	}
-	n.RoomId = r.RoomId
+	delete(r.clients, userId)
+	node.LeaveRoom(r.RoomId)
+	return true
 }
 
 // 推送消息到房间
 func (s *Service) pushRoom(r *Room, data *roomType.QueueMsgData) {
-	r.pushLock.Lock()
+	r.lock.RLock()
 	defer func() {
-		r.pushLock.Unlock()
+		r.lock.RUnlock()
 		if err := recover(); err != nil {
 			logger.Errorf("Room.Push error: %v", err)
 		}
@@ -81,35 +133,72 @@ func (s *Service) pushRoom(r *Room, data *roomType.QueueMsgData) {
 		if data.FromUid == uid {
 			continue
 		}
-		node.DataQueue <- data.MarshalOutput(node.RoomId)
+		node.PushMsg(data.MsgId, data.MarshalOutput(r.RoomId))
 	}
 }
 
 // 离开房间
 func (s *Service) handleLeaveRoom(r *Room, conn *connect.Node) {
-	r.leaveLock.Lock()
-	defer r.leaveLock.Unlock()
-
 	s.roomUserCache.Remove(r.RoomId, conn.UserId)
 	s.userServiceCache.Remove(r.RoomId, conn.UserId)
-	delete(r.clients, conn.UserId)
 
-	conn.RoomId = 0
+	s.unsubscribeRoom(r, conn.UserId)
 }
 
-// 获取房间用户列表
-func (s *Service) roomUserList(roomId uint64) roomType.UserList {
-	userIdNameMap := s.roomUserCache.GetAll(roomId)
+// 获取房间创建者
+func (r *Room) getOwnerId() uint64 {
+	r.infoLock.RLock()
+	defer r.infoLock.RUnlock()
+	return r.ownerId
+}
 
-	var userData = roomType.UserList{}
-	for userId, name := range userIdNameMap {
-		uid, _ := util.StringToUint64(userId)
-		if uid > 0 {
-			userData = append(userData, roomType.UserItem{
-				Id:   uid,
-				Name: name,
-			})
-		}
+// 更新房间创建者
+func (r *Room) setOwnerId(ownerId uint64) {
+	r.infoLock.Lock()
+	defer r.infoLock.Unlock()
+	r.ownerId = ownerId
+}
+
+// 获取房间名称
+func (r *Room) getName() string {
+	r.infoLock.RLock()
+	defer r.infoLock.RUnlock()
+	return r.name
+}
+
+// 更新房间信息
+func (r *Room) setInfo(name string, maxMembers int64) {
+	r.infoLock.Lock()
+	defer r.infoLock.Unlock()
+	r.name = name
+	r.maxMembers = maxMembers
+}
+
+// 获取房间成员上限，0 表示不限制
+func (r *Room) memberLimit() int64 {
+	r.infoLock.RLock()
+	maxMembers := r.maxMembers
+	r.infoLock.RUnlock()
+
+	if maxMembers > 0 {
+		return maxMembers
+	}
+	return config.C.Room.MaxMembers
+}
+
+// 房间成员是否已满。需在事务中调用：锁定房间记录，并发加入的成员串行检查，添加成员后提交事务才不会超出成员上限
+func (s *Service) isRoomFull(ctx context.Context, r *Room) (bool, error) {
+	limit := r.memberLimit()
+	if limit <= 0 {
+		return false, nil
+	}
+
+	if err := s.roomRepo.Lock(ctx, r.RoomId); err != nil {
+		return false, err
+	}
+	count, err := s.roomMemberRepo.Count(ctx, r.RoomId)
+	if err != nil {
+		return false, err
 	}
-	return userData
+	return count >= limit, nil
 }
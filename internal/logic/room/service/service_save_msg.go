@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-im/config"
+	"go-im/internal/connect"
+	"go-im/internal/logic/message/model"
+	"go-im/internal/logic/room/types"
+	"go-im/pkg/errorx"
+	"time"
+)
+
+// 解析并校验消息内容，校验通过后将消息内容替换为格式化后的消息
+func (s *Service) parseContent(n *connect.Node, data *types.Input, method types.MsgMethod) bool {
+	content, err := types.ParseMsgContent(data.Data, contentLimit())
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeValidateError, err.Error())
+		return false
+	}
+
+	data.Data = content
+	return true
+}
+
+// 消息内容限制，使用配置的上传文件大小限制
+func contentLimit() types.ContentLimit {
+	return types.ContentLimit{
+		MaxImageSize: config.C.Upload.MaxImageSize,
+		MaxFileSize:  config.C.Upload.MaxFileSize,
+	}
+}
+
+// 持久化消息（需在消息投递前调用），保存成功后回填服务端消息id及发送时间。
+// 相同 request_id 的消息只保存一次，重复发送时直接返回首次发送的确认结果
+func (s *Service) saveMsg(n *connect.Node, data *types.Input, method types.MsgMethod) bool {
+	if !s.lockRequestId(n, data) {
+		return false
+	}
+
+	var (
+		msg *model.Message
+		err error
+	)
+
+	if method == types.MethodNormal {
+		msg, err = s.msgService.SavePrivateMsg(context.Background(), n.UserId, data.ToUid, data.RequestId, data.Data)
+	} else {
+		msg, err = s.msgService.SaveGroupMsg(context.Background(), n.UserId, data.RoomId, data.RequestId, data.Data)
+	}
+
+	if err != nil {
+		s.unlockRequestId(n, data)
+		s.sendErrorMsg(n, data.RequestId, method, types.CodeError, errorx.Message(err))
+		return false
+	}
+
+	data.MsgId = msg.Id
+	data.Seq = msg.Seq
+	data.SendTime = msg.CreatedAt.UnixMilli()
+
+	s.saveRequestIdResult(n, data)
+	return true
+}
+
+// 标记消息处理中。返回 false 表示重复消息，首次发送的消息已处理完成时，重新发送确认结果
+func (s *Service) lockRequestId(n *connect.Node, data *types.Input) bool {
+	if data.RequestId == "" {
+		return true
+	}
+
+	if s.requestIdCache.Lock(n.UserId, data.RequestId, s.requestIdExpire()) {
+		return true
+	}
+
+	// 首次发送的消息还在处理中，处理完成后会发送确认结果
+	result := s.requestIdCache.Get(n.UserId, data.RequestId)
+	if result == "" {
+		return false
+	}
+
+	var ack types.AckResult
+	if err := json.Unmarshal([]byte(result), &ack); err == nil {
+		s.sendSuccessMsg(n, data.RequestId, types.MethodServiceAck, &ack)
+	}
+	return false
+}
+
+// 消息处理失败，删除标记，允许客户端重试
+func (s *Service) unlockRequestId(n *connect.Node, data *types.Input) {
+	if data.RequestId != "" {
+		s.requestIdCache.Remove(n.UserId, data.RequestId)
+	}
+}
+
+// 保存消息的确认结果
+func (s *Service) saveRequestIdResult(n *connect.Node, data *types.Input) {
+	if data.RequestId == "" {
+		return
+	}
+
+	result, _ := json.Marshal(s.ackResult(data))
+	s.requestIdCache.Set(n.UserId, data.RequestId, result, s.requestIdExpire())
+}
+
+// 消息去重有效时间
+func (s *Service) requestIdExpire() time.Duration {
+	return time.Duration(config.C.Message.DedupExpire) * time.Second
+}
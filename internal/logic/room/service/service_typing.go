@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"go-im/internal/connect"
+	"go-im/internal/logic/room/types"
+	"go-im/pkg/logger"
+	"time"
+)
+
+/**
+ * @Description: 正在输入事件（不持久化、不保存离线消息，也不需要 ack 确认）
+ */
+
+const (
+	typingThrottle = 3 * time.Second // 每个用户正在输入事件的最小转发间隔
+	typingExpire   = 6 * time.Second // 正在输入状态有效时间，超时没有收到新事件时自动发送停止输入事件
+)
+
+// 用户正在输入状态
+type typingState struct {
+	roomId   uint64      // 输入所在房间
+	toUid    uint64      // 输入所在私聊对象
+	lastTime time.Time   // 最后一次转发正在输入事件的时间
+	timer    *time.Timer // 自动停止输入定时器
+	gen      uint64      // 定时器代数，每次重置定时器时递增，用于忽略已过期的定时器回调
+}
+
+// 待转发的正在输入事件（在 typingLock 外转发，避免查询用户信息时阻塞其他用户）
+type typingRelay struct {
+	roomId uint64
+	toUid  uint64
+	typing bool
+}
+
+// 正在输入（传递 to_uid 时转发给私聊对象，否则转发给房间用户）
+func (s *Service) typing(n *connect.Node, data *types.Input) {
+	var req types.TypingEvent
+	if err := data.BindData(&req); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodTyping, types.CodeValidateError, "参数格式有误")
+		return
+	}
+
+	if data.ToUid > 0 {
+		// 对方已屏蔽当前用户时，不转发正在输入事件
+		if s.userService.IsBlocked(context.Background(), data.ToUid, n.UserId) {
+			return
+		}
+		data.RoomId = 0
+	} else if !s.isInRoom(n, data.RoomId) {
+		s.sendErrorMsg(n, data.RequestId, types.MethodTyping, types.CodeValidateError, "未加入群聊")
+		return
+	}
+
+	if req.Typing {
+		s.startTyping(n, data.RoomId, data.ToUid)
+	} else {
+		s.stopTyping(n, data.RoomId, data.ToUid)
+	}
+}
+
+// 开始输入（限流，并重置自动停止输入定时器）
+func (s *Service) startTyping(n *connect.Node, roomId, toUid uint64) {
+	var relays []typingRelay
+
+	s.typingLock.Lock()
+	state, ok := s.typingStates[n.UserId]
+	if ok && state.roomId == roomId && state.toUid == toUid {
+		s.resetTypingTimer(n, state)
+		if time.Since(state.lastTime) >= typingThrottle {
+			state.lastTime = time.Now()
+			relays = append(relays, typingRelay{roomId: roomId, toUid: toUid, typing: true})
+		}
+	} else {
+		// 切换了输入的会话，停止之前会话的输入状态
+		if ok {
+			state.timer.Stop()
+			relays = append(relays, typingRelay{roomId: state.roomId, toUid: state.toUid})
+		}
+
+		state = &typingState{
+			roomId:   roomId,
+			toUid:    toUid,
+			lastTime: time.Now(),
+		}
+		s.resetTypingTimer(n, state)
+		s.typingStates[n.UserId] = state
+		relays = append(relays, typingRelay{roomId: roomId, toUid: toUid, typing: true})
+	}
+	s.typingLock.Unlock()
+
+	for _, relay := range relays {
+		s.relayTyping(n, relay.roomId, relay.toUid, relay.typing)
+	}
+}
+
+// 重置自动停止输入定时器（需持有 typingLock）
+func (s *Service) resetTypingTimer(n *connect.Node, state *typingState) {
+	if state.timer != nil {
+		state.timer.Stop()
+	}
+	state.gen++
+	gen := state.gen
+	state.timer = time.AfterFunc(typingExpire, func() {
+		s.expireTyping(n, state, gen)
+	})
+}
+
+// 输入状态超时，自动停止输入（状态已被替换或定时器已重置时忽略）
+func (s *Service) expireTyping(n *connect.Node, state *typingState, gen uint64) {
+	s.typingLock.Lock()
+	if cur, ok := s.typingStates[n.UserId]; !ok || cur != state || cur.gen != gen {
+		s.typingLock.Unlock()
+		return
+	}
+	delete(s.typingStates, n.UserId)
+	s.typingLock.Unlock()
+
+	s.relayTyping(n, state.roomId, state.toUid, false)
+}
+
+// 停止输入（只有已转发过正在输入事件时才转发停止输入事件）
+func (s *Service) stopTyping(n *connect.Node, roomId, toUid uint64) {
+	s.typingLock.Lock()
+	state, ok := s.typingStates[n.UserId]
+	if !ok || state.roomId != roomId || state.toUid != toUid {
+		s.typingLock.Unlock()
+		return
+	}
+	state.timer.Stop()
+	delete(s.typingStates, n.UserId)
+	s.typingLock.Unlock()
+
+	s.relayTyping(n, roomId, toUid, false)
+}
+
+// 连接关闭时清除正在输入状态
+func (s *Service) clearTyping(n *connect.Node) {
+	s.typingLock.Lock()
+	defer s.typingLock.Unlock()
+
+	if state, ok := s.typingStates[n.UserId]; ok {
+		state.timer.Stop()
+		delete(s.typingStates, n.UserId)
+	}
+}
+
+// 转发正在输入事件
+func (s *Service) relayTyping(n *connect.Node, roomId, toUid uint64, typing bool) {
+	defer func() {
+		// 连接关闭后，广播队列已关闭
+		if err := recover(); err != nil {
+			logger.Errorf("relay typing error: %v", err)
+		}
+	}()
+
+	event := types.TypingEvent{Typing: typing}
+	if typing {
+		event.Expire = int64(typingExpire / time.Second)
+	}
+
+	out := s.getOutput(n, &types.Input{
+		Method: types.MethodTyping.Uint8(),
+		Data:   event,
+		RoomId: roomId,
+		ToUid:  toUid,
+	}).QueueMsgData()
+
+	if toUid > 0 {
+		s.relayUserMsg(out)
+		return
+	}
+
+	n.BroadcastQueue <- out.Marshal()
+	if r := s.getRoom(roomId); r != nil {
+		s.pushRoom(r, out)
+	}
+}
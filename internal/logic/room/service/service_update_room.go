@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go-im/config"
+	"go-im/internal/connect"
+	"go-im/internal/logic/room/model"
+	"go-im/internal/logic/room/types"
+	"strings"
+	"unicode/utf8"
+)
+
+// 房间信息最大长度
+const (
+	roomAvatarMaxLen       = 255
+	roomDescriptionMaxLen  = 200
+	roomAnnouncementMaxLen = 1000
+)
+
+// 修改房间信息（名称、头像、简介、公告、成员上限），通知房间成员及其他服务
+func (s *Service) updateRoom(n *connect.Node, data *types.Input) {
+	r, ok := s.checkPermission(n, data, types.MethodUpdateRoom, types.PermUpdateRoom)
+	if !ok {
+		return
+	}
+
+	var req types.UpdateRoomReq
+	if err := data.BindData(&req); err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodUpdateRoom, types.CodeValidateError, "参数格式有误")
+		return
+	}
+
+	fields := make(map[string]any)
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || utf8.RuneCountInString(name) > roomNameMaxLen {
+			s.sendErrorMsg(n, data.RequestId, types.MethodUpdateRoom, types.CodeValidateError, "房间名称格式有误")
+			return
+		}
+		fields["name"] = name
+	}
+	if req.Avatar != nil {
+		if len(*req.Avatar) > roomAvatarMaxLen {
+			s.sendErrorMsg(n, data.RequestId, types.MethodUpdateRoom, types.CodeValidateError, "房间头像格式有误")
+			return
+		}
+		fields["avatar"] = *req.Avatar
+	}
+	if req.Description != nil {
+		if utf8.RuneCountInString(*req.Description) > roomDescriptionMaxLen {
+			s.sendErrorMsg(n, data.RequestId, types.MethodUpdateRoom, types.CodeValidateError, "房间简介不能超过 200 个字符")
+			return
+		}
+		fields["description"] = *req.Description
+	}
+	if req.Announcement != nil {
+		if !s.roomRole(r, n.UserId).Can(types.PermAnnounce) {
+			s.sendErrorMsg(n, data.RequestId, types.MethodUpdateRoom, types.CodeValidateError, "无权发布公告")
+			return
+		}
+		if utf8.RuneCountInString(*req.Announcement) > roomAnnouncementMaxLen {
+			s.sendErrorMsg(n, data.RequestId, types.MethodUpdateRoom, types.CodeValidateError, "房间公告不能超过 1000 个字符")
+			return
+		}
+		fields["announcement"] = *req.Announcement
+	}
+	if req.MaxMembers != nil {
+		if *req.MaxMembers < 0 {
+			s.sendErrorMsg(n, data.RequestId, types.MethodUpdateRoom, types.CodeValidateError, "成员上限格式有误")
+			return
+		}
+		if limit := config.C.Room.MaxMembers; limit > 0 && *req.MaxMembers > limit {
+			s.sendErrorMsg(n, data.RequestId, types.MethodUpdateRoom, types.CodeValidateError, fmt.Sprintf("成员上限不能超过 %d", limit))
+			return
+		}
+		fields["max_members"] = *req.MaxMembers
+	}
+	if len(fields) == 0 {
+		s.sendErrorMsg(n, data.RequestId, types.MethodUpdateRoom, types.CodeValidateError, "请填写需要修改的内容")
+		return
+	}
+
+	ctx := context.Background()
+	ok, err := s.roomRepo.Update(ctx, r.RoomId, fields)
+	if err != nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodUpdateRoom, types.CodeError, "修改失败，请稍后再试。")
+		return
+	}
+	if !ok {
+		s.sendErrorMsg(n, data.RequestId, types.MethodUpdateRoom, types.CodeValidateError, "房间不存在")
+		return
+	}
+
+	// 以数据库为准刷新缓存
+	roomModel, err := s.roomRepo.GetById(ctx, r.RoomId)
+	if err != nil || roomModel == nil {
+		s.sendErrorMsg(n, data.RequestId, types.MethodUpdateRoom, types.CodeError, "修改失败，请稍后再试。")
+		return
+	}
+	s.setRoomCache(roomModel)
+
+	roomInfo := newRoomInfo(roomModel)
+	s.sendSuccessMsg(n, data.RequestId, types.MethodUpdateRoom, roomInfo)
+
+	// 通知其他服务及用户
+	out := s.getOutput(n, &types.Input{
+		Data:   roomInfo,
+		RoomId: r.RoomId,
+		Method: types.MethodRoomUpdatedNotice.Uint8(),
+	}).QueueMsgData()
+	n.BroadcastQueue <- out.Marshal()
+	s.roomUpdated(out)
+}
+
+// 同步房间信息变更：更新当前服务的房间对象，公开房间通知全部用户（更新房间列表），私有房间只通知房间成员
+func (s *Service) roomUpdated(data *types.QueueMsgData) {
+	var info types.RoomInfo
+	if err := data.BindData(&info); err != nil {
+		return
+	}
+
+	if r := s.getRoom(data.RoomId); r != nil {
+		r.setInfo(info.Name, info.MaxMembers)
+	}
+
+	if info.Private {
+		s.SendRoomMsg(data.RoomId, data)
+		return
+	}
+	connect.PushAll(data)
+}
+
+// 房间信息
+func newRoomInfo(roomModel *model.Room) types.RoomInfo {
+	return types.RoomInfo{
+		Id:           roomModel.Id,
+		Name:         roomModel.Name,
+		Avatar:       roomModel.Avatar,
+		Description:  roomModel.Description,
+		Announcement: roomModel.Announcement,
+		OwnerId:      roomModel.OwnerId,
+		Private:      roomModel.IsPrivate(),
+		JoinApproval: roomModel.JoinApproval,
+		MaxMembers:   roomModel.MaxMembers,
+		CreatedAt:    roomModel.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"go-im/internal/connect"
+	"go-im/internal/logic/room/types"
+)
+
+// 用户资料变更：删除本地缓存的用户名称，用户连接在当前服务时，更新房间在线用户缓存中的名称并通知用户
+func (s *Service) userUpdated(data *types.QueueMsgData) {
+	var item types.UserItem
+	if err := data.BindData(&item); err != nil || item.Id == 0 {
+		return
+	}
+
+	s.userService.RemoveUserName(item.Id)
+
+	node := connect.GetNode(item.Id)
+	if node == nil {
+		return
+	}
+	for _, roomId := range node.RoomIds() {
+		s.roomUserCache.Create(roomId, item.Id, item.Name)
+	}
+	s.pushUser(node, data)
+}
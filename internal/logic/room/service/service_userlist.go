@@ -3,19 +3,62 @@ package service
 import (
 	"go-im/internal/connect"
 	roomType "go-im/internal/logic/room/types"
+	"go-im/pkg/logger"
 )
 
-// 获取房间用户列表
+// 房间在线用户每页数量
+const (
+	userPageSize    = 50
+	userPageMaxSize = 200
+)
+
+// 分页获取房间在线用户列表
 func (s *Service) userList(n *connect.Node, data *roomType.Input) {
-	/*n := connect.GetNode(userId)
-	if n == nil {
-		return
-	}*/
 	if !s.isInRoom(n, data.RoomId) {
 		s.sendErrorMsg(n, data.RequestId, roomType.MethodRoomUser, roomType.CodeValidateError, "请选择房间或群组")
 		return
 	}
 
-	list := s.roomUserList(n.RoomId)
-	s.sendSuccessMsg(n, data.RequestId, roomType.MethodRoomUser, list)
+	var req roomType.UserListReq
+	if err := data.BindData(&req); err != nil || req.Size < 0 {
+		s.sendErrorMsg(n, data.RequestId, roomType.MethodRoomUser, roomType.CodeValidateError, "参数格式有误")
+		return
+	}
+	if req.Size == 0 {
+		req.Size = userPageSize
+	} else if req.Size > userPageMaxSize {
+		req.Size = userPageMaxSize
+	}
+
+	list, cursor, err := s.roomUserCache.Page(data.RoomId, req.Cursor, req.Size)
+	if err != nil {
+		logger.Errorf("page room user error: %v", err)
+		s.sendErrorMsg(n, data.RequestId, roomType.MethodRoomUser, roomType.CodeError, "获取用户列表失败，请稍后再试。")
+		return
+	}
+
+	s.sendSuccessMsg(n, data.RequestId, roomType.MethodRoomUser, roomType.UserPage{
+		List:   list,
+		Total:  s.roomUserCache.Count(data.RoomId),
+		Cursor: cursor,
+	})
+}
+
+// 房间在线人数（私有房间仅房间成员可查看）
+func (s *Service) onlineCount(n *connect.Node, data *roomType.Input) {
+	if data.RoomId == 0 {
+		s.sendErrorMsg(n, data.RequestId, roomType.MethodOnlineCount, roomType.CodeValidateError, "请选择房间或群组")
+		return
+	}
+
+	r := s.syncGetRoom(data.RoomId)
+	if r == nil || (r.private && r.getOwnerId() != n.UserId && !s.isInRoom(n, r.RoomId)) {
+		s.sendErrorMsg(n, data.RequestId, roomType.MethodOnlineCount, roomType.CodeValidateError, "房间不存在")
+		return
+	}
+
+	s.sendSuccessMsg(n, data.RequestId, roomType.MethodOnlineCount, roomType.OnlineCount{
+		RoomId: r.RoomId,
+		Online: s.roomUserCache.Count(r.RoomId),
+	})
 }
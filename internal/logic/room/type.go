@@ -0,0 +1,24 @@
+package room
+
+const (
+	ListDefaultSize = 20  // 房间列表默认每页数量
+	ListMaxSize     = 100 // 房间列表最大每页数量
+)
+
+// 房间列表排序方式
+const (
+	SortCreated = "created_at" // 创建时间
+	SortMembers = "members"    // 成员数量
+)
+
+// ListQuery 房间列表查询条件（只返回用户可见的房间：公开房间及用户已加入的房间）
+type ListQuery struct {
+	UserId  uint64 // 当前用户
+	Keyword string // 房间名称关键字
+	OwnerId uint64 // 创建者
+	Joined  bool   // 只返回已加入的房间
+	Sort    string // 排序方式
+	Desc    bool   // 是否倒序
+	Page    int    // 页码，从 1 开始
+	Size    int    // 每页数量
+}
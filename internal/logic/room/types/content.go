@@ -0,0 +1,219 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+/**
+ * @Description: 消息内容（带类型的消息信封）
+ */
+
+type ContentType string
+
+const (
+	ContentText   ContentType = "text"   // 文本消息
+	ContentImage  ContentType = "image"  // 图片消息
+	ContentFile   ContentType = "file"   // 文件消息
+	ContentSystem ContentType = "system" // 系统消息（只能由服务端发送）
+	ContentCustom ContentType = "custom" // 自定义消息（如：应用卡片）
+)
+
+const (
+	MaxTextLength = 5000     // 文本消息最大字符数
+	MaxCustomSize = 8 << 10  // 自定义消息最大字节数
+	MaxNameLength = 255      // 文件名最大字符数
+	maxImageSide  = 20000    // 图片最大宽高
+	mimeImage     = "image/" // 图片 mime 前缀
+	defaultMime   = "application/octet-stream"
+)
+
+// ContentLimit 消息内容限制（与上传文件的大小限制一致）
+type ContentLimit struct {
+	MaxImageSize int64 // 图片最大字节数
+	MaxFileSize  int64 // 文件最大字节数
+}
+
+// MsgContent 消息内容
+type MsgContent struct {
+	Type ContentType     `json:"type"` // 消息类型
+	Body json.RawMessage `json:"body"` // 消息内容，结构由消息类型决定
+}
+
+// TextBody 文本消息
+type TextBody struct {
+	Text string `json:"text"`
+}
+
+// ImageBody 图片消息
+type ImageBody struct {
+	Url       string `json:"url"`                 // 图片地址
+	Thumbnail string `json:"thumbnail,omitempty"` // 缩略图地址
+	Name      string `json:"name,omitempty"`      // 文件名
+	Size      int64  `json:"size"`                // 文件大小（字节）
+	Mime      string `json:"mime"`                // 文件类型，如：image/png
+	Width     int    `json:"width"`               // 宽度（像素）
+	Height    int    `json:"height"`              // 高度（像素）
+}
+
+// FileBody 文件消息
+type FileBody struct {
+	Url  string `json:"url"`  // 文件地址
+	Name string `json:"name"` // 文件名
+	Size int64  `json:"size"` // 文件大小（字节）
+	Mime string `json:"mime"` // 文件类型
+}
+
+// ParseMsgContent 解析并校验客户端发送的消息内容。兼容旧版客户端直接发送字符串的方式，作为文本消息处理
+func ParseMsgContent(data any, limit ContentLimit) (*MsgContent, error) {
+	if text, ok := data.(string); ok {
+		return NewTextContent(text)
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.New("消息格式有误")
+	}
+
+	var content MsgContent
+	if err = json.Unmarshal(b, &content); err != nil {
+		return nil, errors.New("消息格式有误")
+	}
+
+	if err = content.validate(limit); err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// NewTextContent 创建文本消息
+func NewTextContent(text string) (*MsgContent, error) {
+	body, _ := json.Marshal(TextBody{Text: text})
+	content := &MsgContent{Type: ContentText, Body: body}
+	if err := content.validate(ContentLimit{}); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// 校验消息内容，并将消息体格式化
+func (c *MsgContent) validate(limit ContentLimit) error {
+	if len(c.Body) == 0 || bytes.Equal(c.Body, []byte("null")) {
+		return errors.New("消息内容不能为空")
+	}
+
+	var (
+		body any
+		err  error
+	)
+	switch c.Type {
+	case ContentText:
+		body, err = c.validateText()
+	case ContentImage:
+		body, err = c.validateImage(limit.MaxImageSize)
+	case ContentFile:
+		body, err = c.validateFile(limit.MaxFileSize)
+	case ContentCustom:
+		return c.validateCustom()
+	case ContentSystem:
+		return errors.New("不能发送系统消息")
+	default:
+		return errors.New("消息类型有误")
+	}
+	if err != nil {
+		return err
+	}
+
+	// 去除未定义的字段
+	c.Body, _ = json.Marshal(body)
+	return nil
+}
+
+func (c *MsgContent) validateText() (*TextBody, error) {
+	var body TextBody
+	if err := json.Unmarshal(c.Body, &body); err != nil {
+		return nil, errors.New("文本消息格式有误")
+	}
+
+	if strings.TrimSpace(body.Text) == "" {
+		return nil, errors.New("消息内容不能为空")
+	}
+	if utf8.RuneCountInString(body.Text) > MaxTextLength {
+		return nil, errors.New("消息内容过长")
+	}
+	return &body, nil
+}
+
+func (c *MsgContent) validateImage(maxSize int64) (*ImageBody, error) {
+	var body ImageBody
+	if err := json.Unmarshal(c.Body, &body); err != nil {
+		return nil, errors.New("图片消息格式有误")
+	}
+
+	if !isHttpUrl(body.Url) {
+		return nil, errors.New("图片地址有误")
+	}
+	if body.Thumbnail != "" && !isHttpUrl(body.Thumbnail) {
+		return nil, errors.New("缩略图地址有误")
+	}
+	if body.Size <= 0 || body.Size > maxSize {
+		return nil, errors.New("图片大小有误")
+	}
+	if !strings.HasPrefix(body.Mime, mimeImage) {
+		return nil, errors.New("图片类型有误")
+	}
+	if body.Width <= 0 || body.Height <= 0 || body.Width > maxImageSide || body.Height > maxImageSide {
+		return nil, errors.New("图片尺寸有误")
+	}
+	if utf8.RuneCountInString(body.Name) > MaxNameLength {
+		return nil, errors.New("文件名过长")
+	}
+	return &body, nil
+}
+
+func (c *MsgContent) validateFile(maxSize int64) (*FileBody, error) {
+	var body FileBody
+	if err := json.Unmarshal(c.Body, &body); err != nil {
+		return nil, errors.New("文件消息格式有误")
+	}
+
+	if !isHttpUrl(body.Url) {
+		return nil, errors.New("文件地址有误")
+	}
+	if strings.TrimSpace(body.Name) == "" || utf8.RuneCountInString(body.Name) > MaxNameLength {
+		return nil, errors.New("文件名有误")
+	}
+	if body.Size <= 0 || body.Size > maxSize {
+		return nil, errors.New("文件大小有误")
+	}
+	if body.Mime == "" {
+		body.Mime = defaultMime
+	}
+	return &body, nil
+}
+
+// 自定义消息只校验为 json 对象，由客户端自行解析
+func (c *MsgContent) validateCustom() error {
+	if len(c.Body) > MaxCustomSize {
+		return errors.New("消息内容过长")
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(c.Body, &body); err != nil {
+		return errors.New("自定义消息必须为 json 对象")
+	}
+	return nil
+}
+
+// 是否 http 地址
+func isHttpUrl(str string) bool {
+	u, err := url.Parse(str)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,67 @@
+package types
+
+// CreateRoomReq 创建房间（兼容旧版客户端直接发送房间名称）
+type CreateRoomReq struct {
+	Name         string `json:"name"`
+	Private      bool   `json:"private"`       // 是否私有房间
+	JoinApproval bool   `json:"join_approval"` // 加入是否需要审核
+}
+
+// JoinReq 加入房间
+type JoinReq struct {
+	InviteCode string `json:"invite_code"` // 邀请码（私有房间必填，填写后可不传 room_id）
+}
+
+// CreateInviteReq 创建邀请码
+type CreateInviteReq struct {
+	Expire  int64 `json:"expire"`   // 有效时长（秒），0 表示永久有效
+	MaxUses int   `json:"max_uses"` // 最多使用次数，0 表示不限制
+}
+
+// InviteInfo 邀请码信息
+type InviteInfo struct {
+	Code     string `json:"code"`
+	RoomId   uint64 `json:"room_id"`
+	ExpireAt int64  `json:"expire_at"` // 过期时间（秒），0 表示永久有效
+	MaxUses  int    `json:"max_uses"`
+}
+
+// InviteUserReq 邀请用户加入房间
+type InviteUserReq struct {
+	UserId uint64 `json:"user_id"`
+}
+
+// RoomInviteNotice 房间邀请通知
+type RoomInviteNotice struct {
+	InviteInfo
+	RoomName string `json:"room_name"`
+}
+
+// ApplyJoinReq 申请加入房间
+type ApplyJoinReq struct {
+	Remark string `json:"remark"` // 申请说明
+}
+
+// JoinRequestItem 加入申请
+type JoinRequestItem struct {
+	Id        uint64 `json:"id"`
+	RoomId    uint64 `json:"room_id"`
+	UserId    uint64 `json:"user_id"`
+	Username  string `json:"username"`
+	Remark    string `json:"remark"`
+	CreatedAt int64  `json:"created_at"` // 申请时间（秒）
+}
+
+// HandleJoinRequestReq 审核加入申请
+type HandleJoinRequestReq struct {
+	ApplyId uint64 `json:"apply_id"` // 申请id
+	Approve bool   `json:"approve"`  // 是否通过
+}
+
+// JoinRequestResult 加入申请审核结果
+type JoinRequestResult struct {
+	ApplyId  uint64 `json:"apply_id"`
+	RoomId   uint64 `json:"room_id"`
+	RoomName string `json:"room_name"`
+	Approve  bool   `json:"approve"`
+}
@@ -0,0 +1,34 @@
+package types
+
+// 成员管理操作
+const (
+	ModerateKick   = "kick"   // 移出房间
+	ModerateBan    = "ban"    // 禁止加入房间
+	ModerateUnban  = "unban"  // 允许加入房间
+	ModerateMute   = "mute"   // 禁言
+	ModerateUnmute = "unmute" // 解除禁言
+)
+
+// KickReq 移出成员
+type KickReq struct {
+	UserId uint64 `json:"user_id"`
+}
+
+// RestrictReq 禁止加入房间、禁言
+type RestrictReq struct {
+	UserId   uint64 `json:"user_id"`
+	Duration int64  `json:"duration"` // 限制时长（秒），0 表示永久
+	Cancel   bool   `json:"cancel"`   // 是否解除限制
+}
+
+// ModerateEvent 成员管理通知
+type ModerateEvent struct {
+	Action   string `json:"action"`              // 操作类型
+	UserId   uint64 `json:"user_id"`             // 被操作的用户
+	ExpireAt int64  `json:"expire_at,omitempty"` // 限制截止时间（秒），0 表示永久
+}
+
+// IsEvict 是否需要将用户移出房间
+func (e *ModerateEvent) IsEvict() bool {
+	return e.Action == ModerateKick || e.Action == ModerateBan
+}
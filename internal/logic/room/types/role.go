@@ -0,0 +1,89 @@
+package types
+
+// Role 房间成员角色
+type Role uint8
+
+const (
+	RoleMember Role = iota // 普通成员
+	RoleAdmin              // 管理员
+	RoleOwner              // 创建者
+)
+
+// Permission 房间操作权限
+type Permission uint8
+
+const (
+	PermUpdateRoom  Permission = iota + 1 // 修改房间信息
+	PermAnnounce                          // 发布公告
+	PermKick                              // 移出成员
+	PermMute                              // 禁言成员
+	PermRecallMsg                         // 撤回他人消息
+	PermSetAdmin                          // 设置管理员
+	PermTransfer                          // 转让房间
+	PermCloseRoom                         // 关闭房间
+	PermInvite                            // 创建邀请码
+	PermApproveJoin                       // 审核加入申请
+)
+
+// 角色拥有的权限
+var rolePermissions = map[Role]map[Permission]bool{
+	RoleOwner: {
+		PermUpdateRoom:  true,
+		PermAnnounce:    true,
+		PermKick:        true,
+		PermMute:        true,
+		PermRecallMsg:   true,
+		PermSetAdmin:    true,
+		PermTransfer:    true,
+		PermCloseRoom:   true,
+		PermInvite:      true,
+		PermApproveJoin: true,
+	},
+	RoleAdmin: {
+		PermUpdateRoom:  true,
+		PermAnnounce:    true,
+		PermKick:        true,
+		PermMute:        true,
+		PermRecallMsg:   true,
+		PermInvite:      true,
+		PermApproveJoin: true,
+	},
+}
+
+// Can 是否拥有权限
+func (r Role) Can(p Permission) bool {
+	return rolePermissions[r][p]
+}
+
+// Over 是否高于另一个角色（管理员不能操作其他管理员及创建者）
+func (r Role) Over(o Role) bool {
+	return r > o
+}
+
+func (r Role) Name() string {
+	switch r {
+	case RoleOwner:
+		return "创建者"
+	case RoleAdmin:
+		return "管理员"
+	default:
+		return "成员"
+	}
+}
+
+// SetAdminReq 设置/取消管理员
+type SetAdminReq struct {
+	UserId uint64 `json:"user_id"`
+	Admin  bool   `json:"admin"` // true 设置为管理员，false 取消管理员
+}
+
+// TransferOwnerReq 转让房间
+type TransferOwnerReq struct {
+	UserId uint64 `json:"user_id"` // 新的创建者
+}
+
+// RoleChangeEvent 成员角色变更通知
+type RoleChangeEvent struct {
+	UserId uint64 `json:"user_id"`
+	Role   Role   `json:"role"`
+}
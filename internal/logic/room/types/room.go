@@ -0,0 +1,48 @@
+package types
+
+// UpdateRoomReq 修改房间信息，字段为空时不修改
+type UpdateRoomReq struct {
+	Name         *string `json:"name"`
+	Avatar       *string `json:"avatar"`
+	Description  *string `json:"description"`
+	Announcement *string `json:"announcement"` // 房间公告，需要发布公告权限
+	MaxMembers   *int64  `json:"max_members"`  // 成员上限，0 表示使用默认上限
+}
+
+// UserListReq 分页获取房间在线用户
+type UserListReq struct {
+	Cursor uint64 `json:"cursor"` // 游标（上一页最后一个用户id），第一页传 0
+	Size   int64  `json:"size"`   // 每页数量
+}
+
+// UserPage 房间在线用户分页结果
+type UserPage struct {
+	List   UserList `json:"list"`
+	Total  int64    `json:"total"`  // 在线用户总数
+	Cursor uint64   `json:"cursor"` // 下一页的游标，0 表示没有更多数据
+}
+
+// OnlineCount 房间在线人数
+type OnlineCount struct {
+	RoomId uint64 `json:"room_id"`
+	Online int64  `json:"online"`
+}
+
+// RoomListReq 房间列表查询条件
+type RoomListReq struct {
+	Keyword string `json:"keyword"`  // 房间名称关键字
+	OwnerId uint64 `json:"owner_id"` // 创建者
+	Joined  bool   `json:"joined"`   // 只返回已加入的房间
+	Sort    string `json:"sort"`     // 排序方式：created_at 创建时间（默认）、members 成员数量
+	Desc    bool   `json:"desc"`     // 是否倒序
+	Page    int    `json:"page"`     // 页码，从 1 开始
+	Size    int    `json:"size"`     // 每页数量
+}
+
+// RoomPage 房间列表分页结果
+type RoomPage struct {
+	List  RoomList `json:"list"`
+	Total int64    `json:"total"` // 房间总数
+	Page  int      `json:"page"`
+	Size  int      `json:"size"`
+}
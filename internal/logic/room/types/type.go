@@ -47,13 +47,43 @@ const (
 	MethodOffline                                    // 下线消息
 	MethodCreateRoomNotice                           // 新增房间通知
 	MethodForceOfflineBroadcast                      // 通知用户强制下线
+	MethodHistory                                    // 拉取历史消息
+	MethodSyncMsg                                    // 按序号区间同步消息
+	MethodDeliveryAck                                // 客户端确认已收到消息
+	MethodMarkRead                                   // 标记会话已读
+	MethodTyping                                     // 正在输入
+	MethodRecall                                     // 撤回消息
+	MethodEdit                                       // 编辑消息
+	MethodCloseRoom                                  // 关闭（解散）房间
+	MethodSetAdmin                                   // 设置/取消管理员
+	MethodTransferOwner                              // 转让房间
+	MethodKick                                       // 移出成员
+	MethodBan                                        // 禁止/允许成员加入房间
+	MethodMute                                       // 禁言/解除禁言
+	MethodCreateInvite                               // 创建邀请码
+	MethodApplyJoin                                  // 申请加入房间
+	MethodJoinRequestList                            // 待审核的加入申请列表
+	MethodHandleJoinRequest                          // 审核加入申请
+	MethodUpdateRoom                                 // 修改房间信息
+	MethodOnlineCount                                // 房间在线人数
+	MethodInviteUser                                 // 邀请用户加入房间
 )
 
 // Service method
 const (
-	MethodServiceNotice MsgMethod = iota + 100 // 服务器的消息（如：消息错误、通知等）
-	MethodServiceAck                           // 确认消息
-	MethodNewRoomNotice                        // 新建房间通知
+	MethodServiceNotice     MsgMethod = iota + 100 // 服务器的消息（如：消息错误、通知等）
+	MethodServiceAck                               // 确认消息
+	MethodNewRoomNotice                            // 新建房间通知
+	MethodReadReceipt                              // 已读回执（私聊对方已读消息通知）
+	MethodRoomClosedNotice                         // 房间关闭通知
+	MethodRoleChangeNotice                         // 成员角色变更通知
+	MethodModerateNotice                           // 成员管理通知（移出、禁止加入、禁言）
+	MethodJoinRequestNotice                        // 加入申请通知（通知创建者及管理员）
+	MethodJoinRequestResult                        // 加入申请审核结果（通知申请人）
+	MethodRoomUpdatedNotice                        // 房间信息变更通知
+	MethodFriendNotice                             // 好友通知（好友申请、通过、拒绝、删除）
+	MethodRoomInviteNotice                         // 房间邀请通知
+	MethodUserUpdatedNotice                        // 用户资料变更通知
 )
 
 // 队列数据
@@ -68,6 +98,9 @@ type QueueMsgData struct {
 	RoomId       uint64    `json:"room_id,omitempty"`     // 房间id
 	ToUid        uint64    `json:"to_uid,omitempty"`      // 消息接收者
 	FromServer   string    `json:"from_server,omitempty"` // 消息来源（可能为空，广播时使用）
+	MsgId        uint64    `json:"msg_id,omitempty"`      // 服务端消息id
+	Seq          uint64    `json:"seq,omitempty"`         // 会话内消息序号
+	SendTime     int64     `json:"send_time,omitempty"`   // 消息发送时间（毫秒时间戳）
 }
 
 func (q *QueueMsgData) Marshal() []byte {
@@ -86,7 +119,11 @@ func (q *QueueMsgData) MarshalOutput(roomId uint64) []byte {
 		FromUid:      q.FromUid,
 		FromUsername: q.FromUsername,
 		RoomId:       roomId,
+		ToUid:        q.ToUid,
 		FromServer:   q.FromServer,
+		MsgId:        q.MsgId,
+		Seq:          q.Seq,
+		SendTime:     q.SendTime,
 	}
 
 	if data.Msg == "" {
@@ -107,6 +144,9 @@ type Output struct {
 	RoomId       uint64    `json:"room_id,omitempty"`       // 房间id
 	ToUid        uint64    `json:"to_uid,omitempty"`        // 消息接收者
 	FromServer   string    `json:"from_server,omitempty"`   // 消息来源（广播时使用）
+	MsgId        uint64    `json:"msg_id,omitempty"`        // 服务端消息id
+	Seq          uint64    `json:"seq,omitempty"`           // 会话内消息序号（同一房间或同一对私聊用户内单调递增）
+	SendTime     int64     `json:"send_time,omitempty"`     // 消息发送时间（毫秒时间戳）
 }
 
 func (w *Output) QueueMsgData() *QueueMsgData {
@@ -119,7 +159,11 @@ func (w *Output) QueueMsgData() *QueueMsgData {
 		FromUsername: w.FromUsername,
 		Data:         w.Data,
 		RoomId:       w.RoomId,
+		ToUid:        w.ToUid,
 		FromServer:   w.FromServer,
+		MsgId:        w.MsgId,
+		Seq:          w.Seq,
+		SendTime:     w.SendTime,
 	}
 	return &data
 }
@@ -147,6 +191,9 @@ type Input struct {
 	RoomId    uint64 `json:"room_id,omitempty"`    // 房间id
 	ToUid     uint64 `json:"to_uid,omitempty"`     // 消息接收者
 	FromUid   uint64 `json:"from_uid,omitempty"`   // 消息代理时传递
+	MsgId     uint64 `json:"-"`                    // 服务端消息id（消息持久化后设置）
+	Seq       uint64 `json:"-"`                    // 会话内消息序号（消息持久化后设置）
+	SendTime  int64  `json:"-"`                    // 消息发送时间（消息持久化后设置）
 }
 
 func (i *Input) Marshal() []byte {
@@ -154,6 +201,28 @@ func (i *Input) Marshal() []byte {
 	return result
 }
 
+// BindData 将 Data 解析到指定结构体
+func (i *Input) BindData(v any) error {
+	return bindData(i.Data, v)
+}
+
+// BindData 将 Data 解析到指定结构体（网关转发的消息 Data 为 json 解析后的通用类型）
+func (q *QueueMsgData) BindData(v any) error {
+	return bindData(q.Data, v)
+}
+
+func bindData(data any, v any) error {
+	if data == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
 // MarshalSystemOutput 序列化消息系统下行消息
 func MarshalSystemOutput(m MsgMethod, msg string) []byte {
 	return MarshalOutput(m, msg, 0)
@@ -201,11 +270,28 @@ func (c *CreateRoomResult) Marshal() string {
 	return string(result)
 }
 
+// 消息确认结果
+type AckResult struct {
+	MsgId    uint64 `json:"msg_id,omitempty"`    // 服务端消息id
+	Seq      uint64 `json:"seq,omitempty"`       // 会话内消息序号
+	SendTime int64  `json:"send_time,omitempty"` // 消息发送时间（毫秒时间戳）
+}
+
 type RoomList []RoomInfo
 
 type RoomInfo struct {
-	Id   uint64 `json:"id"`
-	Name string `json:"name"`
+	Id           uint64 `json:"id"`
+	Name         string `json:"name"`
+	Avatar       string `json:"avatar,omitempty"`        // 房间头像
+	Description  string `json:"description,omitempty"`   // 房间简介
+	Announcement string `json:"announcement,omitempty"`  // 房间公告
+	OwnerId      uint64 `json:"owner_id,omitempty"`      // 创建者
+	Private      bool   `json:"private,omitempty"`       // 是否私有房间
+	JoinApproval bool   `json:"join_approval,omitempty"` // 加入是否需要审核
+	MaxMembers   int64  `json:"max_members,omitempty"`   // 成员上限，0 表示使用默认上限
+	Members      int64  `json:"members"`                 // 成员数量
+	CreatedAt    int64  `json:"created_at,omitempty"`    // 创建时间（秒）
+	Unread       int64  `json:"unread"`                  // 未读消息数量
 }
 
 func (i *RoomList) Marshal() string {
@@ -216,3 +302,76 @@ func (i *RoomList) Marshal() string {
 	}
 	return string(result)
 }
+
+// 历史消息请求参数
+type HistoryReq struct {
+	BeforeId uint64 `json:"before_id"` // 拉取该消息id之前的消息，为 0 时从最新消息开始
+	Limit    int    `json:"limit"`     // 拉取数量
+}
+
+// 历史消息
+type MsgItem struct {
+	MsgId        uint64          `json:"msg_id"`
+	FromUid      uint64          `json:"from_uid"`
+	FromUsername string          `json:"from_username,omitempty"`
+	RoomId       uint64          `json:"room_id,omitempty"`
+	ToUid        uint64          `json:"to_uid,omitempty"`
+	Seq          uint64          `json:"seq"`
+	Status       uint8           `json:"status"` // 状态：0 正常 1 已编辑 2 已撤回（已撤回的消息 data 为空）
+	Data         json.RawMessage `json:"data"`
+	SendTime     int64           `json:"send_time"`
+}
+
+// 历史消息结果
+type HistoryResult struct {
+	List    []MsgItem `json:"list"`     // 消息列表（按发送时间正序）
+	HasMore bool      `json:"has_more"` // 是否还有更早的消息
+}
+
+// 按序号同步消息请求参数（用于客户端发现序号缺失时补齐消息）
+type SyncMsgReq struct {
+	FromSeq uint64 `json:"from_seq"` // 起始序号（包含）
+	ToSeq   uint64 `json:"to_seq"`   // 结束序号（包含），为 0 或小于起始序号时同步到最新消息
+}
+
+// 客户端确认收到消息请求参数
+type DeliveryAckReq struct {
+	MsgIds []uint64 `json:"msg_ids"` // 已收到的服务端消息id
+}
+
+// 标记已读请求参数
+type MarkReadReq struct {
+	MsgId uint64 `json:"msg_id"` // 已读到的服务端消息id
+}
+
+// 正在输入事件
+type TypingEvent struct {
+	Typing bool  `json:"typing"`           // 是否正在输入，false 表示停止输入
+	Expire int64 `json:"expire,omitempty"` // 正在输入状态有效时间（秒），超时没有收到新事件视为停止输入
+}
+
+// 撤回、编辑消息请求参数
+type ModifyMsgReq struct {
+	MsgId uint64 `json:"msg_id"`         // 服务端消息id
+	Data  any    `json:"data,omitempty"` // 编辑后的消息内容
+}
+
+// 撤回、编辑消息事件
+type ModifyMsgEvent struct {
+	MsgId uint64 `json:"msg_id"`         // 服务端消息id
+	Seq   uint64 `json:"seq"`            // 消息序号
+	Data  any    `json:"data,omitempty"` // 编辑后的消息内容
+}
+
+// 已读回执
+type ReadReceipt struct {
+	UserId uint64 `json:"user_id"` // 已读的用户
+	MsgId  uint64 `json:"msg_id"`  // 已读到的消息id
+	Seq    uint64 `json:"seq"`     // 已读到的消息序号
+}
+
+// 按序号同步消息结果
+type SyncMsgResult struct {
+	List      []MsgItem `json:"list"`      // 消息列表（按序号正序）
+	Truncated bool      `json:"truncated"` // 区间内还有更多消息，需要从最后一条消息的序号继续同步
+}
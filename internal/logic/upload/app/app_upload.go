@@ -0,0 +1,69 @@
+package app
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go-im/config"
+	"go-im/internal/logic/upload"
+	"go-im/internal/logic/upload/service"
+	"go-im/pkg/response"
+	"net/http"
+)
+
+// multipartOverhead multipart 表单除文件外的额外大小
+const multipartOverhead = 1 << 20
+
+func NewUploadApp() *UploadApp {
+	return &UploadApp{
+		uploadService: service.NewUploadService(),
+	}
+}
+
+type UploadApp struct {
+	uploadService service.IService
+}
+
+// Image 上传图片
+func (a *UploadApp) Image(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, config.C.Upload.MaxImageSize+multipartOverhead)
+	fh, err := c.FormFile(upload.FormField)
+	if err != nil {
+		response.Error(c.Writer, formFileError(err))
+		return
+	}
+
+	result, err := a.uploadService.UploadImage(c, fh)
+	if err != nil {
+		response.Error(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, result)
+}
+
+// File 上传文件
+func (a *UploadApp) File(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, config.C.Upload.MaxFileSize+multipartOverhead)
+	fh, err := c.FormFile(upload.FormField)
+	if err != nil {
+		response.Error(c.Writer, formFileError(err))
+		return
+	}
+
+	result, err := a.uploadService.UploadFile(c, fh)
+	if err != nil {
+		response.Error(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, result)
+}
+
+// 读取上传文件的错误
+func formFileError(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return upload.ErrFileTooLarge
+	}
+	return upload.ErrFileEmpty
+}
@@ -0,0 +1,13 @@
+package upload
+
+import "go-im/pkg/errorx"
+
+var (
+	ErrFileEmpty    = errorx.New(60001, "未获取到上传的文件", "请选择要上传的文件")
+	ErrFileTooLarge = errorx.New(60002, "上传文件超过大小限制", "文件大小超过限制")
+	ErrFileType     = errorx.New(60003, "上传文件类型不在允许范围内", "文件类型不支持")
+	ErrFileName     = errorx.New(60004, "上传文件名为空或过长", "文件名有误")
+	ErrImageDecode  = errorx.New(60005, "图片解析失败，无法生成缩略图", "图片格式有误")
+	ErrStorage      = errorx.New(60006, "文件存储失败", "服务器繁忙，请稍后再试")
+	ErrImageSize    = errorx.New(60007, "图片尺寸超过限制，拒绝解析", "图片尺寸过大")
+)
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-im/config"
+	"go-im/internal/logic/room/types"
+	"go-im/internal/logic/upload"
+	"go-im/pkg/file"
+	"go-im/pkg/storage"
+	"go-im/pkg/util"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/xid"
+)
+
+var _ IService = (*Service)(nil)
+
+type IService interface {
+	UploadImage(ctx context.Context, fh *multipart.FileHeader) (*types.ImageBody, error)
+	UploadFile(ctx context.Context, fh *multipart.FileHeader) (*types.FileBody, error)
+}
+
+func NewUploadService() IService {
+	store, err := storage.New(config.C.Upload.StorageConfig())
+	if err != nil {
+		panic(err)
+	}
+	return &Service{
+		storage: store,
+	}
+}
+
+type Service struct {
+	storage storage.Storage
+}
+
+// UploadImage 上传图片，同时生成缩略图
+func (s *Service) UploadImage(ctx context.Context, fh *multipart.FileHeader) (*types.ImageBody, error) {
+	name, err := checkFile(fh, config.C.Upload.MaxImageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, upload.ErrFileEmpty
+	}
+	defer f.Close()
+
+	mime, err := file.GetMime(f)
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, upload.ErrFileEmpty
+	}
+	ext, ok := upload.ImageMimes[mime]
+	if !ok {
+		return nil, upload.ErrFileType
+	}
+
+	thumb, err := file.Thumbnail(f, config.C.Upload.ThumbnailSize)
+	if err != nil {
+		util.LogError(ctx, err)
+		if errors.Is(err, file.ErrImageTooLarge) {
+			return nil, upload.ErrImageSize
+		}
+		return nil, upload.ErrImageDecode
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		util.LogError(ctx, err)
+		return nil, upload.ErrStorage
+	}
+
+	key := newKey(upload.DirImage)
+	url, err := s.put(ctx, key+ext, f, fh.Size, mime)
+	if err != nil {
+		return nil, err
+	}
+	thumbExt := ".jpg"
+	if thumb.Mime == "image/png" {
+		thumbExt = ".png"
+	}
+	thumbUrl, err := s.put(ctx, key+"_thumb"+thumbExt, bytes.NewReader(thumb.Data), int64(len(thumb.Data)), thumb.Mime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ImageBody{
+		Url:       url,
+		Thumbnail: thumbUrl,
+		Name:      name,
+		Size:      fh.Size,
+		Mime:      mime,
+		Width:     thumb.Width,
+		Height:    thumb.Height,
+	}, nil
+}
+
+// UploadFile 上传文件
+func (s *Service) UploadFile(ctx context.Context, fh *multipart.FileHeader) (*types.FileBody, error) {
+	name, err := checkFile(fh, config.C.Upload.MaxFileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := strings.ToLower(file.GetExt(name))
+	if ext == "" || !slices.Contains(config.C.Upload.FileExts, ext) {
+		return nil, upload.ErrFileType
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, upload.ErrFileEmpty
+	}
+	defer f.Close()
+
+	mime, err := file.GetMime(f)
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, upload.ErrFileEmpty
+	}
+
+	url, err := s.put(ctx, newKey(upload.DirFile)+ext, f, fh.Size, mime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.FileBody{
+		Url:  url,
+		Name: name,
+		Size: fh.Size,
+		Mime: mime,
+	}, nil
+}
+
+// 保存文件
+func (s *Service) put(ctx context.Context, key string, r io.Reader, size int64, mime string) (string, error) {
+	url, err := s.storage.Put(ctx, key, r, size, mime)
+	if err != nil {
+		util.LogError(ctx, err)
+		return "", upload.ErrStorage
+	}
+	return url, nil
+}
+
+// 校验文件名及大小，返回文件名
+func checkFile(fh *multipart.FileHeader, maxSize int64) (string, error) {
+	if fh == nil || fh.Size <= 0 {
+		return "", upload.ErrFileEmpty
+	}
+	if fh.Size > maxSize {
+		return "", upload.ErrFileTooLarge
+	}
+
+	name := strings.TrimSpace(filepath.Base(fh.Filename))
+	if name == "" || name == "." || name == "/" || utf8.RuneCountInString(name) > types.MaxNameLength {
+		return "", upload.ErrFileName
+	}
+	return name, nil
+}
+
+// 生成存储路径（不含后缀），按日期分目录
+func newKey(dir string) string {
+	return fmt.Sprintf("%s/%s/%s", dir, time.Now().Format("20060102"), xid.New().String())
+}
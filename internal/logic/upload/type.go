@@ -0,0 +1,15 @@
+package upload
+
+const (
+	FormField = "file" // 上传文件的表单字段
+
+	DirImage = "images" // 图片存储目录
+	DirFile  = "files"  // 文件存储目录
+)
+
+// ImageMimes 允许上传的图片类型（需要能够解析出宽高及生成缩略图）
+var ImageMimes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
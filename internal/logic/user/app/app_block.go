@@ -0,0 +1,51 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-im/internal/logic/user"
+	"go-im/internal/logic/user/service"
+	"go-im/pkg/response"
+	"go-im/pkg/util"
+	"go-im/pkg/util/context"
+)
+
+func NewBlockApp() *BlockApp {
+	return &BlockApp{
+		userServer: service.NewUserService(),
+	}
+}
+
+type BlockApp struct {
+	userServer service.IService
+}
+
+// List 屏蔽列表
+func (a *BlockApp) List(c *gin.Context) {
+	userId, _ := context.UserIDFromCtx(c)
+	list, err := a.userServer.BlockList(c, userId)
+	response.Dynamic(c.Writer, list, err)
+}
+
+// Block 屏蔽用户
+func (a *BlockApp) Block(c *gin.Context) {
+	var req user.BlockReq
+	if err := c.ShouldBind(&req); err != nil {
+		util.HandleValidatorError(c, err)
+		return
+	}
+
+	userId, _ := context.UserIDFromCtx(c)
+	response.Dynamic(c.Writer, nil, a.userServer.Block(c, userId, req.UserId))
+}
+
+// Unblock 取消屏蔽
+func (a *BlockApp) Unblock(c *gin.Context) {
+	var uri user.UserIdUri
+	if err := c.ShouldBindUri(&uri); err != nil {
+		util.HandleValidatorError(c, err)
+		return
+	}
+
+	userId, _ := context.UserIDFromCtx(c)
+	response.Dynamic(c.Writer, nil, a.userServer.Unblock(c, userId, uri.Id))
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-im/internal/logic/user"
+	"go-im/internal/logic/user/service"
+	"go-im/pkg/response"
+	"go-im/pkg/util"
+	"go-im/pkg/util/context"
+	"strings"
+)
+
+func NewProfileApp() *ProfileApp {
+	return &ProfileApp{
+		userServer: service.NewUserService(),
+	}
+}
+
+type ProfileApp struct {
+	userServer service.IService
+}
+
+// Profile 当前用户的个人资料
+func (a *ProfileApp) Profile(c *gin.Context) {
+	userId, _ := context.UserIDFromCtx(c)
+	profile, err := a.userServer.Profile(c, userId)
+	response.Dynamic(c.Writer, profile, err)
+}
+
+// Show 指定用户的资料
+func (a *ProfileApp) Show(c *gin.Context) {
+	var uri user.UserIdUri
+	if err := c.ShouldBindUri(&uri); err != nil {
+		util.HandleValidatorError(c, err)
+		return
+	}
+
+	profile, err := a.userServer.Profile(c, uri.Id)
+	response.Dynamic(c.Writer, profile, err)
+}
+
+// Search 按账号或昵称前缀搜索用户
+func (a *ProfileApp) Search(c *gin.Context) {
+	var req user.SearchReq
+	if err := c.ShouldBind(&req); err != nil {
+		util.HandleValidatorError(c, err)
+		return
+	}
+	keyword := strings.TrimSpace(req.Keyword)
+	if keyword == "" {
+		response.Error(c.Writer, user.ErrKeywordEmpty)
+		return
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Size == 0 {
+		req.Size = user.SearchDefaultSize
+	} else if req.Size > user.SearchMaxSize {
+		req.Size = user.SearchMaxSize
+	}
+
+	userId, _ := context.UserIDFromCtx(c)
+	list, total, err := a.userServer.Search(c, &user.SearchQuery{
+		UserId:  userId,
+		Keyword: keyword,
+		Page:    req.Page,
+		Size:    req.Size,
+	})
+	response.DynamicPage(c.Writer, list, req.Page, req.Size, int(total), err)
+}
+
+// Update 修改个人资料
+func (a *ProfileApp) Update(c *gin.Context) {
+	var req user.UpdateProfileReq
+	if err := c.ShouldBind(&req); err != nil {
+		util.HandleValidatorError(c, err)
+		return
+	}
+
+	userId, _ := context.UserIDFromCtx(c)
+	profile, err := a.userServer.UpdateProfile(c, userId, &req)
+	response.Dynamic(c.Writer, profile, err)
+}
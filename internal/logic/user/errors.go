@@ -8,4 +8,7 @@ var (
 	ErrUsernameExist    = errorx.New(40003, "账号已存在，重复添加", "账号已存在")
 	ErrPassword         = errorx.New(40004, "密码校验失败", "密码有误")
 	ErrUsernameNotFound = errorx.New(40005, "登录账号不存在", "账号不存在")
+	ErrUserNotFound     = errorx.New(40006, "用户不存在", "用户不存在")
+	ErrBlockSelf        = errorx.New(40007, "不能屏蔽自己", "不能屏蔽自己")
+	ErrKeywordEmpty     = errorx.New(40008, "搜索关键字为空", "请输入搜索关键字")
 )
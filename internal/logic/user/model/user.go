@@ -6,6 +6,8 @@ type User struct {
 	Id        uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT"`                 // 主键
 	Username  string    `gorm:"column:username;NOT NULL"`                             // 账号名称
 	Nickname  string    `gorm:"column:nickname;NOT NULL"`                             // 昵称
+	Avatar    string    `gorm:"column:avatar;NOT NULL"`                               // 头像
+	Bio       string    `gorm:"column:bio;NOT NULL"`                                  // 个人简介
 	Password  string    `gorm:"column:password;NOT NULL"`                             // 密码
 	CreatedAt time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 创建时间
 	UpdatedAt time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 更新时间
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// UserBlock 用户屏蔽列表（被屏蔽的用户不能给用户发送私聊消息、好友申请及房间邀请）
+type UserBlock struct {
+	Id        uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT"`                 // 主键
+	UserId    uint64    `gorm:"column:user_id;NOT NULL"`                              // 用户id
+	BlockId   uint64    `gorm:"column:block_id;NOT NULL"`                             // 被屏蔽的用户id
+	CreatedAt time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL"` // 屏蔽时间
+}
+
+func (m *UserBlock) TableName() string {
+	return "user_block"
+}
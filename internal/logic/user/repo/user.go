@@ -57,3 +57,59 @@ func (d *UserRepo) GetById(ctx context.Context, id uint64) (*model.User, error)
 	}
 	return &userModel, nil
 }
+
+// ListByIds 通过id批量获取用户信息
+func (d *UserRepo) ListByIds(ctx context.Context, ids []uint64) ([]*model.User, error) {
+	var list []*model.User
+	if len(ids) == 0 {
+		return list, nil
+	}
+
+	err := d.db.DB.Where("id IN ?", ids).Find(&list).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, user.ErrDBOperate
+	}
+	return list, nil
+}
+
+// Update 更新用户信息
+func (d *UserRepo) Update(ctx context.Context, id uint64, fields map[string]any) error {
+	err := d.db.DB.Model(&model.User{}).Where("id = ?", id).Updates(fields).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return user.ErrDBOperate
+	}
+	return nil
+}
+
+// Search 按账号或昵称前缀搜索用户（排除当前用户及屏蔽了当前用户的用户），返回用户列表及总数
+func (d *UserRepo) Search(ctx context.Context, query *user.SearchQuery) ([]*model.User, int64, error) {
+	keyword := util.EscapeLike(query.Keyword) + "%"
+	blockedBy := d.db.DB.Model(&model.UserBlock{}).Select("user_id").Where("block_id = ?", query.UserId)
+
+	tx := d.db.DB.WithContext(ctx).Model(&model.User{}).
+		Where("username LIKE ? OR nickname LIKE ?", keyword, keyword).
+		Where("id <> ?", query.UserId).
+		Where("id NOT IN (?)", blockedBy)
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		util.LogError(ctx, err)
+		return nil, 0, user.ErrDBOperate
+	}
+
+	var list []*model.User
+	if total == 0 {
+		return list, 0, nil
+	}
+	err := tx.Order("id ASC").
+		Offset((query.Page - 1) * query.Size).
+		Limit(query.Size).
+		Find(&list).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, 0, user.ErrDBOperate
+	}
+	return list, total, nil
+}
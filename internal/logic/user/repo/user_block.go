@@ -0,0 +1,70 @@
+package repo
+
+import (
+	"context"
+	"go-im/internal/logic/user"
+	"go-im/internal/logic/user/model"
+	"go-im/pkg/mysql"
+	"go-im/pkg/util"
+	"gorm.io/gorm/clause"
+)
+
+func NewUserBlockRepo() *UserBlockRepo {
+	return &UserBlockRepo{
+		db: mysql.GetMysqlClient(mysql.DefaultClient),
+	}
+}
+
+type UserBlockRepo struct {
+	db *mysql.DB
+}
+
+// Add 屏蔽用户，已屏蔽时忽略
+func (d *UserBlockRepo) Add(ctx context.Context, userId, blockId uint64) error {
+	err := mysql.GetDB(ctx, d.db.DB).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.UserBlock{UserId: userId, BlockId: blockId}).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return user.ErrDBOperate
+	}
+	return nil
+}
+
+// Remove 取消屏蔽
+func (d *UserBlockRepo) Remove(ctx context.Context, userId, blockId uint64) error {
+	err := mysql.GetDB(ctx, d.db.DB).
+		Where("user_id = ? AND block_id = ?", userId, blockId).
+		Delete(&model.UserBlock{}).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return user.ErrDBOperate
+	}
+	return nil
+}
+
+// Exists 用户 userId 是否屏蔽了 blockId
+func (d *UserBlockRepo) Exists(ctx context.Context, userId, blockId uint64) (bool, error) {
+	var count int64
+	err := mysql.GetDB(ctx, d.db.DB).Model(&model.UserBlock{}).
+		Where("user_id = ? AND block_id = ?", userId, blockId).
+		Count(&count).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return false, user.ErrDBOperate
+	}
+	return count > 0, nil
+}
+
+// List 获取用户的屏蔽列表
+func (d *UserBlockRepo) List(ctx context.Context, userId uint64) ([]*model.UserBlock, error) {
+	var list []*model.UserBlock
+	err := mysql.GetDB(ctx, d.db.DB).
+		Where("user_id = ?", userId).
+		Order("id DESC").
+		Find(&list).Error
+	if err != nil {
+		util.LogError(ctx, err)
+		return nil, user.ErrDBOperate
+	}
+	return list, nil
+}
@@ -13,6 +13,7 @@ import (
 	"go-im/pkg/util/consul"
 	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/sync/singleflight"
+	"strings"
 )
 
 var _ IService = (*Service)(nil)
@@ -24,11 +25,31 @@ type IService interface {
 	GetImServer(ctx context.Context) *user2.ImServerResult
 
 	UserIdName(userId uint64) string
+	// 删除本地缓存的用户名称
+	RemoveUserName(userId uint64)
+
+	// 搜索用户
+	Search(ctx context.Context, query *user2.SearchQuery) ([]*user2.Profile, int64, error)
+
+	// 获取个人资料
+	Profile(ctx context.Context, userId uint64) (*user2.Profile, error)
+	// 修改个人资料，并通知全部 IM 服务更新用户名称
+	UpdateProfile(ctx context.Context, userId uint64, req *user2.UpdateProfileReq) (*user2.Profile, error)
+
+	// 屏蔽用户
+	Block(ctx context.Context, userId, blockId uint64) error
+	// 取消屏蔽
+	Unblock(ctx context.Context, userId, blockId uint64) error
+	// 屏蔽列表
+	BlockList(ctx context.Context, userId uint64) ([]*user2.BlockItem, error)
+	// 用户 userId 是否屏蔽了 blockId
+	IsBlocked(ctx context.Context, userId, blockId uint64) bool
 }
 
 func NewUserService() IService {
 	return &Service{
 		userRepo:      repo.NewUserRepo(),
+		userBlockRepo: repo.NewUserBlockRepo(),
 		f:             singleflight.Group{},
 		userNameCache: cache.NewLruList(1000),
 	}
@@ -36,6 +57,7 @@ func NewUserService() IService {
 
 type Service struct {
 	userRepo      *repo.UserRepo
+	userBlockRepo *repo.UserBlockRepo
 	f             singleflight.Group
 	userNameCache *cache.LruCache
 }
@@ -156,12 +178,16 @@ func (u *Service) UserIdName(userId uint64) string {
 	v, err, _ := u.f.Do(key, func() (any, error) {
 		return u.userRepo.GetById(context.Background(), userId)
 	})
-	if err != nil || v == nil {
+	if err != nil {
 		logger.Errorf("get user info error: %v", err)
 		return ""
 	}
 
 	userInfo := v.(*model.User)
+	if userInfo == nil { // 用户不存在
+		return ""
+	}
+
 	username := userInfo.Username
 	if userInfo.Nickname != "" {
 		username = userInfo.Nickname
@@ -170,6 +196,159 @@ func (u *Service) UserIdName(userId uint64) string {
 	return username
 }
 
+// Search 按账号或昵称前缀搜索用户
+func (u *Service) Search(ctx context.Context, query *user2.SearchQuery) ([]*user2.Profile, int64, error) {
+	list, total, err := u.userRepo.Search(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*user2.Profile, 0, len(list))
+	for _, item := range list {
+		result = append(result, newProfile(item))
+	}
+	return result, total, nil
+}
+
+// RemoveUserName 删除本地缓存的用户名称
+func (u *Service) RemoveUserName(userId uint64) {
+	u.userNameCache.Remove(userId)
+}
+
+// Profile 获取个人资料
+func (u *Service) Profile(ctx context.Context, userId uint64) (*user2.Profile, error) {
+	userInfo, err := u.userRepo.GetById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if userInfo == nil {
+		return nil, user2.ErrUserNotFound
+	}
+	return newProfile(userInfo), nil
+}
+
+// UpdateProfile 修改个人资料
+func (u *Service) UpdateProfile(ctx context.Context, userId uint64, req *user2.UpdateProfileReq) (*user2.Profile, error) {
+	fields := make(map[string]any)
+	if req.Nickname != nil {
+		fields["nickname"] = strings.TrimSpace(*req.Nickname)
+	}
+	if req.Avatar != nil {
+		fields["avatar"] = *req.Avatar
+	}
+	if req.Bio != nil {
+		fields["bio"] = *req.Bio
+	}
+	if len(fields) > 0 {
+		if err := u.userRepo.Update(ctx, userId, fields); err != nil {
+			return nil, err
+		}
+	}
+
+	profile, err := u.Profile(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := fields["nickname"]; ok {
+		u.RemoveUserName(userId)
+		u.userUpdatedNotify(profile)
+	}
+	return profile, nil
+}
+
+// 通知全部 IM 服务用户资料已变更
+func (u *Service) userUpdatedNotify(profile *user2.Profile) {
+	name := profile.Username
+	if profile.Nickname != "" {
+		name = profile.Nickname
+	}
+
+	data := types.QueueMsgData{
+		Method:  types.MethodUserUpdatedNotice,
+		FromUid: profile.Id,
+		Data: types.UserItem{
+			Id:   profile.Id,
+			Name: name,
+		},
+	}
+	connect.SendGatewayMsg(data.Marshal())
+}
+
+func newProfile(userInfo *model.User) *user2.Profile {
+	return &user2.Profile{
+		Id:        userInfo.Id,
+		Username:  userInfo.Username,
+		Nickname:  userInfo.Nickname,
+		Avatar:    userInfo.Avatar,
+		Bio:       userInfo.Bio,
+		CreatedAt: userInfo.CreatedAt.Unix(),
+	}
+}
+
+// Block 屏蔽用户
+func (u *Service) Block(ctx context.Context, userId, blockId uint64) error {
+	if userId == blockId {
+		return user2.ErrBlockSelf
+	}
+
+	target, err := u.userRepo.GetById(ctx, blockId)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return user2.ErrUserNotFound
+	}
+
+	return u.userBlockRepo.Add(ctx, userId, blockId)
+}
+
+// Unblock 取消屏蔽
+func (u *Service) Unblock(ctx context.Context, userId, blockId uint64) error {
+	return u.userBlockRepo.Remove(ctx, userId, blockId)
+}
+
+// BlockList 屏蔽列表
+func (u *Service) BlockList(ctx context.Context, userId uint64) ([]*user2.BlockItem, error) {
+	list, err := u.userBlockRepo.List(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint64, 0, len(list))
+	for _, item := range list {
+		ids = append(ids, item.BlockId)
+	}
+	users, err := u.userRepo.ListByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	userMap := make(map[uint64]*model.User, len(users))
+	for _, item := range users {
+		userMap[item.Id] = item
+	}
+
+	result := make([]*user2.BlockItem, 0, len(list))
+	for _, item := range list {
+		blockItem := &user2.BlockItem{
+			Id:        item.BlockId,
+			CreatedAt: item.CreatedAt.Unix(),
+		}
+		if userInfo, ok := userMap[item.BlockId]; ok {
+			blockItem.Username = userInfo.Username
+			blockItem.Nickname = userInfo.Nickname
+		}
+		result = append(result, blockItem)
+	}
+	return result, nil
+}
+
+// IsBlocked 用户 userId 是否屏蔽了 blockId（查询失败时按未屏蔽处理，不影响正常通信）
+func (u *Service) IsBlocked(ctx context.Context, userId, blockId uint64) bool {
+	blocked, err := u.userBlockRepo.Exists(ctx, userId, blockId)
+	return err == nil && blocked
+}
+
 // 创建密码
 func (u *Service) hashPassword(password string) (string, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -1,5 +1,10 @@
 package user
 
+const (
+	SearchDefaultSize = 20 // 用户搜索默认每页数量
+	SearchMaxSize     = 50 // 用户搜索最大每页数量
+)
+
 type RegisterReq struct {
 	Username string `binding:"required,min=3,max=20" form:"username" json:"username" xml:"username" label:"账号"`
 	Nickname string `binding:"max=20" form:"nickname" json:"nickname" xml:"nickname" label:"昵称"`
@@ -33,3 +38,51 @@ type LoginResult struct {
 type ImServerResult struct {
 	ServerAddress string `json:"server_addr"` // websocket 地址
 }
+
+type BlockReq struct {
+	UserId uint64 `binding:"required" form:"user_id" json:"user_id" xml:"user_id" label:"用户"`
+}
+
+type UserIdUri struct {
+	Id uint64 `binding:"required" uri:"id" label:"用户"`
+}
+
+// BlockItem 屏蔽的用户
+type BlockItem struct {
+	Id        uint64 `json:"id"`
+	Username  string `json:"username"`
+	Nickname  string `json:"nickname"`
+	CreatedAt int64  `json:"created_at"` // 屏蔽时间（秒）
+}
+
+// UpdateProfileReq 修改个人资料，字段为空时不修改
+type UpdateProfileReq struct {
+	Nickname *string `binding:"omitempty,max=20" form:"nickname" json:"nickname" xml:"nickname" label:"昵称"`
+	Avatar   *string `binding:"omitempty,max=255" form:"avatar" json:"avatar" xml:"avatar" label:"头像"`
+	Bio      *string `binding:"omitempty,max=200" form:"bio" json:"bio" xml:"bio" label:"个人简介"`
+}
+
+// Profile 个人资料
+type Profile struct {
+	Id        uint64 `json:"id"`
+	Username  string `json:"username"`
+	Nickname  string `json:"nickname"`
+	Avatar    string `json:"avatar"`
+	Bio       string `json:"bio"`
+	CreatedAt int64  `json:"created_at"` // 注册时间（秒）
+}
+
+// SearchReq 搜索用户
+type SearchReq struct {
+	Keyword string `binding:"required,max=20" form:"keyword" json:"keyword" xml:"keyword" label:"关键字"`
+	Page    int    `binding:"omitempty,min=1" form:"page" json:"page" xml:"page" label:"页码"`
+	Size    int    `binding:"omitempty,min=1" form:"size" json:"size" xml:"size" label:"每页数量"`
+}
+
+// SearchQuery 用户搜索条件
+type SearchQuery struct {
+	UserId  uint64 // 当前用户（排除自己及屏蔽了当前用户的用户）
+	Keyword string // 账号或昵称前缀
+	Page    int    // 页码，从 1 开始
+	Size    int    // 每页数量
+}
@@ -13,6 +13,11 @@ type LruCache struct {
 	lock     sync.Mutex
 }
 
+type entry struct {
+	key   any
+	value any
+}
+
 func NewLruList(size int) *LruCache {
 	values := list.New()
 
@@ -26,38 +31,62 @@ func NewLruList(size int) *LruCache {
 func (l *LruCache) Put(k, v any) {
 	l.lock.Lock()
 	defer l.lock.Unlock()
+	if e, ok := l.cacheMap[k]; ok {
+		e.Value.(*entry).value = v
+		l.values.MoveToFront(e)
+		return
+	}
+
 	if l.values.Len() == l.size {
 		back := l.values.Back()
 		l.values.Remove(back)
-		delete(l.cacheMap, back)
+		delete(l.cacheMap, back.Value.(*entry).key)
 	}
 
-	front := l.values.PushFront(v)
+	front := l.values.PushFront(&entry{key: k, value: v})
 	l.cacheMap[k] = front
 }
 
 func (l *LruCache) Get(k any) (any, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
 	v, ok := l.cacheMap[k]
 	if ok {
 		l.values.MoveToFront(v)
-		return v.Value, true
+		return v.Value.(*entry).value, true
 	} else {
 		return nil, false
 	}
 }
 
+// Remove 删除缓存
+func (l *LruCache) Remove(k any) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if e, ok := l.cacheMap[k]; ok {
+		l.values.Remove(e)
+		delete(l.cacheMap, k)
+	}
+}
+
 func (l *LruCache) Size() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
 	return l.values.Len()
 }
 func (l *LruCache) String() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
 	for i := l.values.Front(); i != nil; i = i.Next() {
-		fmt.Print(i.Value, "\t")
+		fmt.Print(i.Value.(*entry).value, "\t")
 	}
 }
 func (l *LruCache) List() []any {
+	l.lock.Lock()
+	defer l.lock.Unlock()
 	var data []any
 	for i := l.values.Front(); i != nil; i = i.Next() {
-		data = append(data, i.Value)
+		data = append(data, i.Value.(*entry).value)
 	}
 	return data
 }
@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLruCache(t *testing.T) {
+	c := NewLruList(2)
+	c.Put(1, "a")
+	c.Put(2, "b")
+	c.Put(1, "c") // 更新已存在的 key
+	assert.Equal(t, 2, c.Size())
+	assert.Equal(t, []any{"c", "b"}, c.List())
+
+	c.Put(3, "d") // 淘汰最久未使用的 key 2
+	_, ok := c.Get(2)
+	assert.False(t, ok)
+	v, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "c", v)
+
+	c.Remove(1)
+	_, ok = c.Get(1)
+	assert.False(t, ok)
+	assert.Equal(t, 1, c.Size())
+
+	c.Clear()
+	assert.Equal(t, 0, c.Size())
+}
+
+func TestLruCacheConcurrent(t *testing.T) {
+	c := NewLruList(8)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c.Put(i*100+j, j)
+				c.Get(i*100 + j)
+				_ = c.Size()
+				_ = c.List()
+				c.Remove(i*100 + j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 0, c.Size())
+}
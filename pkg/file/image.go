@@ -0,0 +1,163 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+const (
+	thumbnailQuality = 80         // jpeg quality of the thumbnail
+	MaxImageSide     = 16384      // max width or height of an image to decode
+	MaxImagePixels   = 40_000_000 // max pixel count of an image to decode
+)
+
+// ErrImageTooLarge the declared image size exceeds MaxImageSide or MaxImagePixels
+var ErrImageTooLarge = errors.New("image too large")
+
+// Thumb thumbnail of an image
+type Thumb struct {
+	Data   []byte // thumbnail content
+	Mime   string // thumbnail mime type
+	Width  int    // original image width
+	Height int    // original image height
+}
+
+// GetMime detect the mime type by the file header, and rewind the reader
+func GetMime(f io.ReadSeeker) (string, error) {
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// Thumbnail decode the image and scale it down proportionally so that the longest side does not exceed maxSide.
+// The declared size is checked before decoding, images larger than MaxImageSide or MaxImagePixels are rejected with ErrImageTooLarge.
+// png and gif thumbnails are encoded as png to keep transparency, the others as jpeg
+func Thumbnail(r io.ReadSeeker, maxSide int) (*Thumb, error) {
+	if maxSide <= 0 {
+		return nil, fmt.Errorf("invalid thumbnail size: %d", maxSide)
+	}
+
+	cfg, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, fmt.Errorf("image.DecodeConfig err: %v", err)
+	}
+	if cfg.Width > MaxImageSide || cfg.Height > MaxImageSide || cfg.Width*cfg.Height > MaxImagePixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrImageTooLarge, cfg.Width, cfg.Height)
+	}
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	src, format, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("image.Decode err: %v", err)
+	}
+
+	b := src.Bounds()
+	thumb := &Thumb{Width: b.Dx(), Height: b.Dy()}
+	if thumb.Width == 0 || thumb.Height == 0 {
+		return nil, fmt.Errorf("invalid image size: %dx%d", thumb.Width, thumb.Height)
+	}
+
+	w, h := thumb.Width, thumb.Height
+	if w > maxSide || h > maxSide {
+		if w >= h {
+			h = max(h*maxSide/w, 1)
+			w = maxSide
+		} else {
+			w = max(w*maxSide/h, 1)
+			h = maxSide
+		}
+	}
+	dst := resize(src, w, h)
+
+	buf := new(bytes.Buffer)
+	switch format {
+	case "png", "gif":
+		thumb.Mime = "image/png"
+		err = png.Encode(buf, dst)
+	default:
+		thumb.Mime = "image/jpeg"
+		err = jpeg.Encode(buf, dst, &jpeg.Options{Quality: thumbnailQuality})
+	}
+	if err != nil {
+		return nil, err
+	}
+	thumb.Data = buf.Bytes()
+
+	return thumb, nil
+}
+
+// resize scale the image down (w, h must not exceed the source size) by averaging the source pixels covered by each target pixel.
+// The source is converted row by row with draw.Draw, which has fast paths for the common decoded image types
+func resize(src image.Image, w, h int) *image.NRGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+
+	// target column of each source column, and the number of source columns of each target column
+	cols := make([]int, sw)
+	colCount := make([]uint64, w)
+	for sx := 0; sx < sw; sx++ {
+		cols[sx] = sx * w / sw
+		colCount[cols[sx]]++
+	}
+
+	row := image.NewRGBA(image.Rect(0, 0, sw, 1)) // one source row, alpha premultiplied
+	sums := make([]uint64, w*4)
+	var rows uint64
+	for sy := 0; sy < sh; sy++ {
+		draw.Draw(row, row.Rect, src, image.Pt(b.Min.X, b.Min.Y+sy), draw.Src)
+		for sx, tx := range cols {
+			p, s := row.Pix[sx*4:sx*4+4:sx*4+4], sums[tx*4:tx*4+4:tx*4+4]
+			s[0] += uint64(p[0])
+			s[1] += uint64(p[1])
+			s[2] += uint64(p[2])
+			s[3] += uint64(p[3])
+		}
+		rows++
+
+		ty := sy * h / sh
+		if sy+1 < sh && (sy+1)*h/sh == ty {
+			continue
+		}
+
+		// the source rows of the target row are complete
+		for tx := 0; tx < w; tx++ {
+			s := sums[tx*4 : tx*4+4 : tx*4+4]
+			if a, n := s[3], colCount[tx]*rows; a > 0 {
+				dst.SetNRGBA(tx, ty, color.NRGBA{
+					R: unpremultiply(s[0], a),
+					G: unpremultiply(s[1], a),
+					B: unpremultiply(s[2], a),
+					A: uint8((a + n/2) / n),
+				})
+			}
+			s[0], s[1], s[2], s[3] = 0, 0, 0, 0
+		}
+		rows = 0
+	}
+
+	return dst
+}
+
+// unpremultiply convert the sum of premultiplied color values to the straight color value
+func unpremultiply(c, a uint64) uint8 {
+	return uint8(min((c*255+a/2)/a, 255))
+}
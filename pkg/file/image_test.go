@@ -0,0 +1,145 @@
+package file
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThumbnail(t *testing.T) {
+	cases := []struct {
+		name       string
+		width      int
+		height     int
+		encode     func(*bytes.Buffer, image.Image) error
+		maxSide    int
+		wantMime   string
+		wantWidth  int
+		wantHeight int
+	}{
+		{
+			name:       "landscape png",
+			width:      400,
+			height:     200,
+			encode:     func(b *bytes.Buffer, m image.Image) error { return png.Encode(b, m) },
+			maxSide:    100,
+			wantMime:   "image/png",
+			wantWidth:  100,
+			wantHeight: 50,
+		},
+		{
+			name:       "portrait jpeg",
+			width:      150,
+			height:     300,
+			encode:     func(b *bytes.Buffer, m image.Image) error { return jpeg.Encode(b, m, nil) },
+			maxSide:    100,
+			wantMime:   "image/jpeg",
+			wantWidth:  50,
+			wantHeight: 100,
+		},
+		{
+			name:       "small image",
+			width:      40,
+			height:     30,
+			encode:     func(b *bytes.Buffer, m image.Image) error { return png.Encode(b, m) },
+			maxSide:    100,
+			wantMime:   "image/png",
+			wantWidth:  40,
+			wantHeight: 30,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			src := image.NewRGBA(image.Rect(0, 0, c.width, c.height))
+			for x := 0; x < c.width; x++ {
+				src.Set(x, 0, color.RGBA{R: 255, A: 255})
+			}
+			buf := new(bytes.Buffer)
+			assert.Nil(t, c.encode(buf, src))
+
+			thumb, err := Thumbnail(bytes.NewReader(buf.Bytes()), c.maxSide)
+			assert.Nil(t, err)
+			assert.Equal(t, c.width, thumb.Width)
+			assert.Equal(t, c.height, thumb.Height)
+			assert.Equal(t, c.wantMime, thumb.Mime)
+
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(thumb.Data))
+			assert.Nil(t, err)
+			assert.Equal(t, c.wantWidth, cfg.Width)
+			assert.Equal(t, c.wantHeight, cfg.Height)
+		})
+	}
+
+	_, err := Thumbnail(bytes.NewReader([]byte("not an image")), 100)
+	assert.NotNil(t, err)
+}
+
+func TestThumbnailAverage(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		src.Set(x, 0, color.NRGBA{R: 255, A: 255})
+		src.Set(x, 1, color.NRGBA{B: 255, A: 255})
+	}
+	// the right half is transparent, and must not darken the color
+	src.Set(3, 0, color.NRGBA{})
+	src.Set(3, 1, color.NRGBA{})
+
+	dst := resize(src, 2, 1)
+	assert.Equal(t, color.NRGBA{R: 128, B: 128, A: 255}, dst.NRGBAAt(0, 0))
+	assert.Equal(t, color.NRGBA{R: 128, B: 128, A: 128}, dst.NRGBAAt(1, 0))
+}
+
+func TestThumbnailTooLarge(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, png.Encode(buf, image.NewGray(image.Rect(0, 0, 1, 1))))
+	data := buf.Bytes()
+
+	// rewrite the size in the IHDR chunk (after the 8 bytes signature, 4 bytes length and 4 bytes type) and its crc
+	setSize := func(w, h uint32) []byte {
+		b := bytes.Clone(data)
+		binary.BigEndian.PutUint32(b[16:20], w)
+		binary.BigEndian.PutUint32(b[20:24], h)
+		binary.BigEndian.PutUint32(b[29:33], crc32.ChecksumIEEE(b[12:29]))
+		return b
+	}
+
+	cases := []struct {
+		name string
+		w, h uint32
+	}{
+		{name: "width", w: MaxImageSide + 1, h: 1},
+		{name: "height", w: 1, h: MaxImageSide + 1},
+		{name: "pixels", w: 10000, h: 10000},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Thumbnail(bytes.NewReader(setSize(c.w, c.h)), 100)
+			assert.True(t, errors.Is(err, ErrImageTooLarge), "%v", err)
+		})
+	}
+
+	// the patched header is still a valid png
+	thumb, err := Thumbnail(bytes.NewReader(setSize(1, 1)), 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, thumb.Width)
+}
+
+func TestGetMime(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 1, 1))))
+	r := bytes.NewReader(buf.Bytes())
+
+	mime, err := GetMime(r)
+	assert.Nil(t, err)
+	assert.Equal(t, "image/png", mime)
+	assert.Equal(t, int64(buf.Len()), int64(r.Len()))
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local store the files on local disk
+type Local struct {
+	dir     string
+	baseUrl string
+}
+
+func NewLocal(dir, baseUrl string) *Local {
+	return &Local{
+		dir:     dir,
+		baseUrl: baseUrl,
+	}
+}
+
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(filepath.Dir(name), os.ModePerm); err != nil {
+		return "", fmt.Errorf("os.MkdirAll err: %v", err)
+	}
+
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return "", fmt.Errorf("os.OpenFile err: %v", err)
+	}
+
+	n, err := io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && size >= 0 && n != size {
+		err = fmt.Errorf("size mismatch, want %d, got %d", size, n)
+	}
+	if err != nil {
+		_ = os.Remove(name)
+		return "", err
+	}
+
+	return joinUrl(l.baseUrl, key), nil
+}
+
+// path get the local path of the key, and the key can not escape the storage directory
+func (l *Local) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid key: %s", key)
+	}
+
+	return filepath.Join(l.dir, clean), nil
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// Storage file storage
+type Storage interface {
+	// Put save the content under the key, and return the access url
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error)
+}
+
+// Config storage config
+type Config struct {
+	Driver   string // local or s3
+	LocalDir string // local storage directory
+	BaseUrl  string // access url prefix
+	S3       S3Config
+}
+
+// New create a storage by the driver
+func New(cfg Config) (Storage, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		return NewLocal(cfg.LocalDir, cfg.BaseUrl), nil
+	case DriverS3:
+		if cfg.S3.PublicUrl == "" {
+			cfg.S3.PublicUrl = cfg.BaseUrl
+		}
+		return NewS3(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Driver)
+	}
+}
+
+// joinUrl join the url prefix and the key
+func joinUrl(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(key, "/")
+}
@@ -1,7 +1,9 @@
 package util
 
 import (
+	"crypto/rand"
 	"fmt"
+	"math/big"
 	"net"
 	"strconv"
 	"strings"
@@ -72,3 +74,26 @@ func StringToUint64(str string) (uint64, error) {
 	}
 	return strconv.ParseUint(str, 10, 64)
 }
+
+const randChars = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz23456789"
+
+// RandString 生成指定长度的随机字符串（去除了易混淆的字符）
+func RandString(n int) string {
+	b := make([]byte, n)
+	total := big.NewInt(int64(len(randChars)))
+	for i := range b {
+		idx, err := rand.Int(rand.Reader, total)
+		if err != nil {
+			panic(err)
+		}
+		b[i] = randChars[idx.Int64()]
+	}
+	return string(b)
+}
+
+var likeReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike 转义 sql LIKE 语句中的通配符
+func EscapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
@@ -100,3 +100,32 @@ func TestCheckIsIp(t *testing.T) {
 		})
 	}
 }
+
+func TestRandString(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		s := RandString(8)
+		assert.Len(t, s, 8)
+		for _, c := range s {
+			assert.Contains(t, randChars, string(c))
+		}
+		assert.False(t, seen[s])
+		seen[s] = true
+	}
+	assert.Equal(t, "", RandString(0))
+}
+
+func TestEscapeLike(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{in: "room", want: "room"},
+		{in: "100%", want: `100\%`},
+		{in: "a_b", want: `a\_b`},
+		{in: `a\b`, want: `a\\b`},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, EscapeLike(c.in))
+	}
+}